@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned by Get when the key is not cached or has expired
+var ErrMiss = errors.New("cache miss")
+
+// Cache stores serialized values with a time to live. Values can be tagged with the data they
+// were built from, so they are invalidated together when that data changes
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores value under key and adds key to each of the tags
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error
+	Delete(ctx context.Context, keys ...string) error
+	// Invalidate deletes every key added to any of the tags and increments their versions
+	Invalidate(ctx context.Context, tags ...string) error
+	// TagVersions returns the current version of each of the tags, 0 for a tag never invalidated
+	TagVersions(ctx context.Context, tags ...string) (map[string]int64, error)
+	// SetIfUnchanged is Set with the tags of versions, unless any of them was invalidated since its
+	// version was read. stored is false when the value was left out
+	SetIfUnchanged(ctx context.Context, key string, value []byte, ttl time.Duration, versions map[string]int64) (stored bool, err error)
+	// Lock acquires an exclusive lock named after key for at most ttl, acquired is false while
+	// another caller holds it. release frees the lock if it is still held by this caller
+	Lock(ctx context.Context, key string, ttl time.Duration) (release func(), acquired bool, err error)
+}
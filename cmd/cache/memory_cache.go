@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryCache keeps the values in process memory, for tests and single instance deployments.
+// Expired entries are dropped when they are read
+type memoryCache struct {
+	mu       sync.Mutex
+	entries  map[string]memoryEntry
+	tags     map[string]map[string]struct{}
+	versions map[string]int64
+	locks    map[string]*time.Time
+	now      func() time.Time
+}
+
+func NewMemoryCache() *memoryCache {
+	return &memoryCache{
+		entries:  map[string]memoryEntry{},
+		tags:     map[string]map[string]struct{}{},
+		versions: map[string]int64{},
+		locks:    map[string]*time.Time{},
+		now:      time.Now,
+	}
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[key]
+	if !found {
+		return nil, ErrMiss
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, ErrMiss
+	}
+	return entry.value, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value, ttl, tags)
+	return nil
+}
+
+func (c *memoryCache) set(key string, value []byte, ttl time.Duration, tags []string) {
+	c.entries[key] = memoryEntry{value: value, expiresAt: c.now().Add(ttl)}
+	for _, tag := range tags {
+		if c.tags[tag] == nil {
+			c.tags[tag] = map[string]struct{}{}
+		}
+		c.tags[tag][key] = struct{}{}
+	}
+}
+
+func (c *memoryCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func (c *memoryCache) Invalidate(_ context.Context, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			delete(c.entries, key)
+		}
+		delete(c.tags, tag)
+		c.versions[tag]++
+	}
+	return nil
+}
+
+func (c *memoryCache) TagVersions(_ context.Context, tags ...string) (map[string]int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	versions := make(map[string]int64, len(tags))
+	for _, tag := range tags {
+		versions[tag] = c.versions[tag]
+	}
+	return versions, nil
+}
+
+func (c *memoryCache) SetIfUnchanged(_ context.Context, key string, value []byte, ttl time.Duration, versions map[string]int64) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tags := make([]string, 0, len(versions))
+	for tag, version := range versions {
+		if c.versions[tag] != version {
+			return false, nil
+		}
+		tags = append(tags, tag)
+	}
+	c.set(key, value, ttl, tags)
+	return true, nil
+}
+
+func (c *memoryCache) Lock(_ context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if expiresAt, held := c.locks[key]; held && c.now().Before(*expiresAt) {
+		return nil, false, nil
+	}
+	// The address of the expiry identifies the holder, a lock taken over after expiring is not released
+	expiresAt := c.now().Add(ttl)
+	c.locks[key] = &expiresAt
+	release := func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.locks[key] == &expiresAt {
+			delete(c.locks, key)
+		}
+	}
+	return release, true, nil
+}
@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Deletes the members of every tag set in the first half of KEYS, then the sets themselves, and
+// increments the version of each tag in the second half. Keys are deleted in batches to stay below
+// the limit of arguments unpacked into a single call
+var invalidateScript = redis.NewScript(`
+local deleted = 0
+local tags = #KEYS / 2
+for t = 1, tags do
+	local keys = redis.call('SMEMBERS', KEYS[t])
+	for i = 1, #keys, 1000 do
+		deleted = deleted + redis.call('DEL', unpack(keys, i, math.min(i + 999, #keys)))
+	end
+	redis.call('DEL', KEYS[t])
+	redis.call('INCR', KEYS[tags + t])
+end
+return deleted
+`)
+
+// errTagInvalidated aborts SetIfUnchanged when a tag version no longer matches
+var errTagInvalidated = errors.New("cache tag invalidated")
+
+// Deletes the lock in KEYS[1] only if it still holds the token of the caller in ARGV[1]
+var unlockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// redisCache shares the values between all instances. Client is the pooled client created at
+// startup, it is not closed by the cache
+type redisCache struct {
+	Client *redis.Client
+}
+
+func NewRedisCache(client *redis.Client) *redisCache {
+	return &redisCache{Client: client}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.Client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return value, err
+}
+
+// Set stores the value and adds key to the set of each tag in a single transaction. A tag set lives
+// as long as its longest lived key, expired keys are left in it until the tag is invalidated
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	_, err := c.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		setTagged(ctx, pipe, key, value, ttl, tags)
+		return nil
+	})
+	return err
+}
+
+func setTagged(ctx context.Context, pipe redis.Pipeliner, key string, value []byte, ttl time.Duration, tags []string) {
+	pipe.Set(ctx, key, value, ttl)
+	for _, tag := range tags {
+		pipe.SAdd(ctx, tagKey(tag), key)
+		pipe.ExpireNX(ctx, tagKey(tag), ttl)
+		pipe.ExpireGT(ctx, tagKey(tag), ttl)
+	}
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.Client.Del(ctx, keys...).Err()
+}
+
+func (c *redisCache) Invalidate(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	keys := make([]string, 2*len(tags))
+	for i, tag := range tags {
+		keys[i] = tagKey(tag)
+		keys[len(tags)+i] = versionKey(tag)
+	}
+	return invalidateScript.Run(ctx, c.Client, keys).Err()
+}
+
+func (c *redisCache) TagVersions(ctx context.Context, tags ...string) (map[string]int64, error) {
+	return tagVersions(ctx, c.Client, tags)
+}
+
+// SetIfUnchanged watches the version keys of the tags, so an invalidation between the versions check
+// and the write aborts the transaction
+func (c *redisCache) SetIfUnchanged(ctx context.Context, key string, value []byte, ttl time.Duration, versions map[string]int64) (bool, error) {
+	tags := make([]string, 0, len(versions))
+	keys := make([]string, 0, len(versions))
+	for tag := range versions {
+		tags = append(tags, tag)
+		keys = append(keys, versionKey(tag))
+	}
+	err := c.Client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tagVersions(ctx, tx, tags)
+		if err != nil {
+			return err
+		}
+		for tag, version := range versions {
+			if current[tag] != version {
+				return errTagInvalidated
+			}
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			setTagged(ctx, pipe, key, value, ttl, tags)
+			return nil
+		})
+		return err
+	}, keys...)
+	if errors.Is(err, errTagInvalidated) || errors.Is(err, redis.TxFailedErr) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// tagVersions reads the version keys of the tags, a missing key is a tag never invalidated
+func tagVersions(ctx context.Context, client redis.Cmdable, tags []string) (map[string]int64, error) {
+	versions := make(map[string]int64, len(tags))
+	if len(tags) == 0 {
+		return versions, nil
+	}
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = versionKey(tag)
+	}
+	values, err := client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		if value == nil {
+			versions[tags[i]] = 0
+			continue
+		}
+		version, err := strconv.ParseInt(value.(string), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		versions[tags[i]] = version
+	}
+	return versions, nil
+}
+
+func tagKey(tag string) string {
+	return "cache:tag:" + tag
+}
+
+func versionKey(tag string) string {
+	return "cache:version:" + tag
+}
+
+// Lock is a SET NX with a random token, so only the holder releases it. Locks are shared by all
+// instances and expire with ttl should the holder never release them
+func (c *redisCache) Lock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, false, err
+	}
+	token := hex.EncodeToString(secret)
+
+	acquired, err := c.Client.SetNX(ctx, lockKey(key), token, ttl).Result()
+	if err != nil || !acquired {
+		return nil, false, err
+	}
+	release := func() {
+		_ = unlockScript.Run(context.Background(), c.Client, []string{lockKey(key)}, token).Err()
+	}
+	return release, true, nil
+}
+
+func lockKey(key string) string {
+	return "cache:lock:" + key
+}
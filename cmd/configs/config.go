@@ -0,0 +1,205 @@
+package configs
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/ratelimit"
+)
+
+// Config is the configuration of the service, see Load for where it is read from
+type Config struct {
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	Cache      CacheConfig
+	Auth       AuthConfig
+	Log        LogConfig
+	Purge      PurgeConfig
+	RateLimits map[string]ratelimit.Limit
+}
+
+type ServerConfig struct {
+	Port int
+	// How long the server waits on clients to read a request and to write a response, and
+	// keeps idle keep-alive connections open
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// How long readiness fails before the server stops, and how long in-flight requests are
+	// then waited for
+	ShutdownDelay   time.Duration
+	ShutdownTimeout time.Duration
+	// Timeout of each dependency check of the readiness probe
+	HealthCheckTimeout time.Duration
+	// IPs or CIDRs of the proxies whose X-Forwarded-For is trusted for the client IP, none by
+	// default so clients cannot pick the IP they are rate limited by
+	TrustedProxies []string
+}
+
+func (c ServerConfig) Addr() string {
+	return ":" + strconv.Itoa(c.Port)
+}
+
+type DatabaseConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Name     string
+	// Connection pool sizing, a zero MaxOpenConns means no limit
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// Queries slower than this are logged as slow
+	SlowQueryThreshold time.Duration
+}
+
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		c.Username, c.Password, net.JoinHostPort(c.Host, strconv.Itoa(c.Port)), c.Name)
+}
+
+type RedisConfig struct {
+	Host     string
+	Port     int
+	Password string
+	DB       int
+	TLS      bool
+	// Connections per CPU kept by the client, zero keeps the go-redis default
+	PoolSize int
+}
+
+func (c RedisConfig) Addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+type CacheConfig struct {
+	// How long the generated reports are fresh
+	ReportTTL time.Duration
+	// How long after expiring a report is still served while it is regenerated in the background,
+	// zero disables stale-while-revalidate
+	ReportStaleTTL time.Duration
+	// How long a report generation may take, other callers wait as long for it before generating
+	// the report themselves
+	ReportLockTimeout time.Duration
+}
+
+type AuthConfig struct {
+	// HMAC key used to sign the JWTs
+	JWTSecret  string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	// Initial admin account, created at startup when both are set
+	AdminEmail    string
+	AdminPassword string
+}
+
+type LogConfig struct {
+	// One of debug, info, warn or error
+	Level string
+}
+
+type PurgeConfig struct {
+	// How long soft deleted rows are kept, and how often they are purged
+	Retention time.Duration
+	Interval  time.Duration
+}
+
+// Default returns the configuration used for every key that is not set
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:               8080,
+			ReadTimeout:        15 * time.Second,
+			WriteTimeout:       30 * time.Second,
+			IdleTimeout:        60 * time.Second,
+			ShutdownDelay:      5 * time.Second,
+			ShutdownTimeout:    30 * time.Second,
+			HealthCheckTimeout: 2 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Port:               3306,
+			MaxOpenConns:       25,
+			MaxIdleConns:       10,
+			ConnMaxLifetime:    30 * time.Minute,
+			ConnMaxIdleTime:    5 * time.Minute,
+			SlowQueryThreshold: 200 * time.Millisecond,
+		},
+		Redis: RedisConfig{
+			Port: 6379,
+		},
+		Cache: CacheConfig{
+			ReportTTL:         5 * time.Minute,
+			ReportLockTimeout: 25 * time.Second,
+		},
+		Auth: AuthConfig{
+			AccessTTL:  15 * time.Minute,
+			RefreshTTL: 7 * 24 * time.Hour,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Purge: PurgeConfig{
+			Retention: 30 * 24 * time.Hour,
+			Interval:  24 * time.Hour,
+		},
+		RateLimits: map[string]ratelimit.Limit{
+			"default": {Requests: 120, Window: time.Minute},
+			// Every request of an IP address, shared by the clients behind it
+			"ip":      {Requests: 600, Window: time.Minute},
+			"auth":    {Requests: 10, Window: time.Minute},
+			"reports": {Requests: 30, Window: time.Minute},
+		},
+	}
+}
+
+// ConfigError lists every key that is missing or has an invalid value, by environment variable name
+type ConfigError struct {
+	Missing []string
+	Invalid []string
+}
+
+func (e *ConfigError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Invalid) > 0 {
+		problems = append(problems, "invalid "+strings.Join(e.Invalid, ", "))
+	}
+	return "invalid configuration: " + strings.Join(problems, "; ")
+}
+
+// parseRateLimits parses a comma separated list of group=requests/window, e.g. "default=120/1m,reports=30/1m"
+func parseRateLimits(value string) (map[string]ratelimit.Limit, error) {
+	limits := map[string]ratelimit.Limit{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		group, limit, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("%q is not group=requests/window", entry)
+		}
+		requests, window, found := strings.Cut(limit, "/")
+		if !found {
+			return nil, fmt.Errorf("%q is not group=requests/window", entry)
+		}
+		count, err := strconv.Atoi(requests)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("%q has an invalid request count", entry)
+		}
+		duration, err := time.ParseDuration(window)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("%q has an invalid window", entry)
+		}
+		limits[group] = ratelimit.Limit{Requests: count, Window: duration}
+	}
+	return limits, nil
+}
@@ -1,8 +1,9 @@
 package configs
 
 import (
-	"fmt"
-	"os"
+	"log/slog"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -10,18 +11,28 @@ import (
 
 var DB *gorm.DB
 
-func ConnectDB() {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		os.Getenv("DB_USERNAME"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_NAME"),
-	)
-	database, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+// ConnectDB opens the connection pool of the database. Connections are only made when they are
+// first used, so the service starts while MySQL is down and reports it on the readiness probe
+func ConnectDB(config DatabaseConfig, logger *slog.Logger) error {
+	// Neither ping MySQL nor ask for its version on open, both need a connection
+	database, err := gorm.Open(mysql.New(mysql.Config{DSN: config.DSN(), SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger:               logging.NewGormLogger(logger, config.SlowQueryThreshold),
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Size the connection pool
+	sqlDB, err := database.DB()
 	if err != nil {
-		panic("Failed to connect to database!")
+		return err
 	}
+	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(config.ConnMaxIdleTime)
 
 	DB = database
+	return nil
 }
@@ -0,0 +1,336 @@
+package configs
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// binding ties a configuration value to its key in the config file, its environment variable
+// and its command line flag, which is the key with dashes, e.g. -database-max-open-conns
+type binding struct {
+	key      string
+	env      string
+	required bool
+	set      func(value string) error
+}
+
+func (b binding) flag() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(b.key)
+}
+
+func (c *Config) bindings() []binding {
+	return []binding{
+		{key: "server.port", env: "SERVER_PORT", set: portVar(&c.Server.Port)},
+		{key: "server.read_timeout", env: "SERVER_READ_TIMEOUT", set: durationVar(&c.Server.ReadTimeout)},
+		{key: "server.write_timeout", env: "SERVER_WRITE_TIMEOUT", set: durationVar(&c.Server.WriteTimeout)},
+		{key: "server.idle_timeout", env: "SERVER_IDLE_TIMEOUT", set: durationVar(&c.Server.IdleTimeout)},
+		{key: "server.shutdown_delay", env: "SHUTDOWN_DELAY", set: durationVar(&c.Server.ShutdownDelay)},
+		{key: "server.shutdown_timeout", env: "SHUTDOWN_TIMEOUT", set: positiveDurationVar(&c.Server.ShutdownTimeout)},
+		{key: "server.health_check_timeout", env: "HEALTH_CHECK_TIMEOUT", set: positiveDurationVar(&c.Server.HealthCheckTimeout)},
+		{key: "server.trusted_proxies", env: "TRUSTED_PROXIES", set: proxiesVar(&c.Server.TrustedProxies)},
+
+		{key: "database.host", env: "DB_HOST", required: true, set: stringVar(&c.Database.Host)},
+		{key: "database.port", env: "DB_PORT", set: portVar(&c.Database.Port)},
+		{key: "database.username", env: "DB_USERNAME", required: true, set: stringVar(&c.Database.Username)},
+		{key: "database.password", env: "DB_PASSWORD", set: stringVar(&c.Database.Password)},
+		{key: "database.name", env: "DB_NAME", required: true, set: stringVar(&c.Database.Name)},
+		{key: "database.max_open_conns", env: "DB_MAX_OPEN_CONNS", set: intVar(&c.Database.MaxOpenConns)},
+		{key: "database.max_idle_conns", env: "DB_MAX_IDLE_CONNS", set: intVar(&c.Database.MaxIdleConns)},
+		{key: "database.conn_max_lifetime", env: "DB_CONN_MAX_LIFETIME", set: durationVar(&c.Database.ConnMaxLifetime)},
+		{key: "database.conn_max_idle_time", env: "DB_CONN_MAX_IDLE_TIME", set: durationVar(&c.Database.ConnMaxIdleTime)},
+		{key: "database.slow_query_threshold", env: "SLOW_QUERY_THRESHOLD", set: durationVar(&c.Database.SlowQueryThreshold)},
+
+		{key: "redis.host", env: "REDIS_HOST", required: true, set: stringVar(&c.Redis.Host)},
+		{key: "redis.port", env: "REDIS_PORT", set: portVar(&c.Redis.Port)},
+		{key: "redis.password", env: "REDIS_PASSWORD", set: stringVar(&c.Redis.Password)},
+		{key: "redis.db", env: "REDIS_DB", set: intVar(&c.Redis.DB)},
+		{key: "redis.tls", env: "REDIS_TLS", set: boolVar(&c.Redis.TLS)},
+		{key: "redis.pool_size", env: "REDIS_POOL_SIZE", set: intVar(&c.Redis.PoolSize)},
+
+		{key: "cache.report_ttl", env: "REPORT_CACHE_TTL", set: positiveDurationVar(&c.Cache.ReportTTL)},
+		{key: "cache.report_stale_ttl", env: "REPORT_CACHE_STALE_TTL", set: durationVar(&c.Cache.ReportStaleTTL)},
+		{key: "cache.report_lock_timeout", env: "REPORT_CACHE_LOCK_TIMEOUT", set: positiveDurationVar(&c.Cache.ReportLockTimeout)},
+
+		{key: "auth.jwt_secret", env: "JWT_SECRET", required: true, set: secretVar(&c.Auth.JWTSecret)},
+		{key: "auth.access_ttl", env: "JWT_ACCESS_TTL", set: positiveDurationVar(&c.Auth.AccessTTL)},
+		{key: "auth.refresh_ttl", env: "JWT_REFRESH_TTL", set: positiveDurationVar(&c.Auth.RefreshTTL)},
+		{key: "auth.admin_email", env: "ADMIN_EMAIL", set: stringVar(&c.Auth.AdminEmail)},
+		{key: "auth.admin_password", env: "ADMIN_PASSWORD", set: secretVar(&c.Auth.AdminPassword)},
+
+		{key: "log.level", env: "LOG_LEVEL", set: logLevelVar(&c.Log.Level)},
+
+		{key: "purge.retention", env: "SOFT_DELETE_RETENTION", set: positiveDurationVar(&c.Purge.Retention)},
+		{key: "purge.interval", env: "PURGE_INTERVAL", set: positiveDurationVar(&c.Purge.Interval)},
+
+		{key: "rate_limits", env: "RATE_LIMITS", set: rateLimitsVar(c)},
+	}
+}
+
+// Load reads the configuration from, in increasing order of precedence: the defaults, the YAML or
+// TOML file given by -config or CONFIG_FILE, the environment and the command line flags.
+// All the missing and invalid keys are reported at once in a *ConfigError
+func Load(args []string) (Config, error) {
+	config := Default()
+	bindings := config.bindings()
+
+	// Flags are parsed first to find the config file, but only applied last
+	flags := flag.NewFlagSet("elabram-backend", flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "path of a YAML or TOML configuration file")
+	flagValues := map[string]string{}
+	for _, b := range bindings {
+		flags.Func(b.flag(), "overrides "+b.env, func(value string) error {
+			flagValues[b.key] = value
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return config, err
+	}
+
+	values := map[string]string{}
+	if *configFile != "" {
+		fileValues, err := readConfigFile(*configFile)
+		if err != nil {
+			return config, err
+		}
+		for key, value := range fileValues {
+			values[key] = value
+		}
+	}
+	for _, b := range bindings {
+		if value := os.Getenv(b.env); value != "" {
+			values[b.key] = value
+		}
+	}
+	for key, value := range flagValues {
+		values[key] = value
+	}
+
+	configErr := &ConfigError{}
+	known := map[string]bool{}
+	for _, b := range bindings {
+		known[b.key] = true
+		value := values[b.key]
+		if value == "" {
+			if b.required {
+				configErr.Missing = append(configErr.Missing, b.env)
+			}
+			continue
+		}
+		if err := b.set(value); err != nil {
+			configErr.Invalid = append(configErr.Invalid, fmt.Sprintf("%s (%v)", b.env, err))
+		}
+	}
+	for key := range values {
+		if !known[key] {
+			configErr.Invalid = append(configErr.Invalid, fmt.Sprintf("%s (unknown key in %s)", key, *configFile))
+		}
+	}
+	if config.Database.MaxOpenConns > 0 && config.Database.MaxIdleConns > config.Database.MaxOpenConns {
+		configErr.Invalid = append(configErr.Invalid, "DB_MAX_IDLE_CONNS (greater than DB_MAX_OPEN_CONNS)")
+	}
+
+	// A report generation cut at the lock timeout answers 504, which the write timeout must leave time for
+	if config.Server.WriteTimeout > 0 && config.Cache.ReportLockTimeout >= config.Server.WriteTimeout {
+		configErr.Invalid = append(configErr.Invalid, "REPORT_CACHE_LOCK_TIMEOUT (must be less than SERVER_WRITE_TIMEOUT)")
+	}
+
+	if len(configErr.Missing) > 0 || len(configErr.Invalid) > 0 {
+		sort.Strings(configErr.Invalid)
+		return config, configErr
+	}
+	return config, nil
+}
+
+// readConfigFile returns the values of a YAML or TOML file by key, nested tables are flattened
+// into dotted keys. Rate limits are a table of group = "requests/window"
+func readConfigFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	document := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	case ".toml":
+		err = toml.Unmarshal(content, &document)
+	default:
+		return nil, fmt.Errorf("config file %s is neither YAML nor TOML", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	if rateLimits, ok := document["rate_limits"].(map[string]any); ok {
+		var entries []string
+		for group, limit := range rateLimits {
+			entries = append(entries, fmt.Sprintf("%s=%v", group, limit))
+		}
+		values["rate_limits"] = strings.Join(entries, ",")
+		delete(document, "rate_limits")
+	}
+	flatten("", document, values)
+	return values, nil
+}
+
+func flatten(prefix string, document map[string]any, values map[string]string) {
+	for key, value := range document {
+		if table, ok := value.(map[string]any); ok {
+			flatten(prefix+key+".", table, values)
+			continue
+		}
+		if list, ok := value.([]any); ok {
+			items := make([]string, len(list))
+			for i, item := range list {
+				items[i] = fmt.Sprint(item)
+			}
+			values[prefix+key] = strings.Join(items, ",")
+			continue
+		}
+		values[prefix+key] = fmt.Sprint(value)
+	}
+}
+
+func stringVar(target *string) func(string) error {
+	return func(value string) error {
+		*target = value
+		return nil
+	}
+}
+
+// placeholderSecrets are the sample values of secrets found in docs and compose files, which must
+// never reach a deployment
+var placeholderSecrets = map[string]bool{
+	"change-me": true,
+	"changeme":  true,
+	"secret":    true,
+	"password":  true,
+	"admin123":  true,
+}
+
+func secretVar(target *string) func(string) error {
+	return func(value string) error {
+		if placeholderSecrets[strings.ToLower(value)] {
+			return errors.New("must not be a placeholder value")
+		}
+		*target = value
+		return nil
+	}
+}
+
+func intVar(target *int) func(string) error {
+	return func(value string) error {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 0 {
+			return errors.New("must be a positive integer")
+		}
+		*target = number
+		return nil
+	}
+}
+
+func portVar(target *int) func(string) error {
+	return func(value string) error {
+		port, err := strconv.Atoi(value)
+		if err != nil || port < 1 || port > 65535 {
+			return errors.New("must be a port number")
+		}
+		*target = port
+		return nil
+	}
+}
+
+func boolVar(target *bool) func(string) error {
+	return func(value string) error {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		*target = enabled
+		return nil
+	}
+}
+
+func durationVar(target *time.Duration) func(string) error {
+	return func(value string) error {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration < 0 {
+			return errors.New("must be a duration, e.g. 30s")
+		}
+		*target = duration
+		return nil
+	}
+}
+
+// proxiesVar parses a comma separated list of IPs or CIDRs
+func proxiesVar(target *[]string) func(string) error {
+	return func(value string) error {
+		var proxies []string
+		for _, proxy := range strings.Split(value, ",") {
+			proxy = strings.TrimSpace(proxy)
+			if proxy == "" {
+				continue
+			}
+			if net.ParseIP(proxy) == nil {
+				if _, _, err := net.ParseCIDR(proxy); err != nil {
+					return fmt.Errorf("%q is neither an IP nor a CIDR", proxy)
+				}
+			}
+			proxies = append(proxies, proxy)
+		}
+		*target = proxies
+		return nil
+	}
+}
+
+// positiveDurationVar is durationVar for the durations that zero would break, e.g. a zero
+// PURGE_INTERVAL cannot tick and a zero JWT_ACCESS_TTL issues expired tokens
+func positiveDurationVar(target *time.Duration) func(string) error {
+	return func(value string) error {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return errors.New("must be a duration greater than zero, e.g. 30s")
+		}
+		*target = duration
+		return nil
+	}
+}
+
+func logLevelVar(target *string) func(string) error {
+	return func(value string) error {
+		switch level := strings.ToLower(value); level {
+		case "debug", "info", "warn", "error":
+			*target = level
+			return nil
+		}
+		return errors.New("must be one of debug, info, warn or error")
+	}
+}
+
+// rateLimitsVar overrides the default limits of the groups it lists
+func rateLimitsVar(config *Config) func(string) error {
+	return func(value string) error {
+		limits, err := parseRateLimits(value)
+		if err != nil {
+			return err
+		}
+		for group, limit := range limits {
+			config.RateLimits[group] = limit
+		}
+		return nil
+	}
+}
@@ -0,0 +1,13 @@
+package configs
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
+)
+
+// NewLogger returns the JSON logger of the service
+func NewLogger(config LogConfig) *slog.Logger {
+	return logging.New(os.Stdout, config.Level)
+}
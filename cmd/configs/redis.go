@@ -1,18 +1,24 @@
 package configs
 
 import (
-	"fmt"
-	"os"
+	"crypto/tls"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
-func ClientRedis() *redis.Client {
-	addr := fmt.Sprintf("%s:%s",
-		os.Getenv("REDIS_HOST"),
-		os.Getenv("REDIS_PORT"),
-	)
-	return redis.NewClient(&redis.Options{
-		Addr: addr,
-	})
+func ClientRedis(config RedisConfig) *redis.Client {
+	options := &redis.Options{
+		Addr:     config.Addr(),
+		Password: config.Password,
+		DB:       config.DB,
+		PoolSize: config.PoolSize,
+	}
+	if config.TLS {
+		options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, ServerName: config.Host}
+	}
+	client := redis.NewClient(options)
+	// Trace every command, a failure to instrument only costs the spans
+	_ = redisotel.InstrumentTracing(client)
+	return client
 }
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type apiKeyController struct {
+	Service services.APIKeyService
+}
+
+type APIKeyController interface {
+	CreateAPIKey(ctx *gin.Context)
+	GetAllAPIKeys(ctx *gin.Context)
+	DeleteAPIKey(ctx *gin.Context)
+}
+
+func NewAPIKeyController(service services.APIKeyService) *apiKeyController {
+	return &apiKeyController{Service: service}
+}
+
+func (c *apiKeyController) CreateAPIKey(ctx *gin.Context) {
+	var request models.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		reason := utils.HandleUnmarshalTypeError(err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": reason})
+		return
+	}
+
+	// Validate API key fields
+	validationErrors := utils.ValidateStruct(request)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	apiKey, err := c.Service.CreateAPIKey(ctx, request)
+	if err != nil {
+		if errors.Is(err, models.ErrExpiryInPast) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"errors": []string{err.Error()}})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, apiKey)
+}
+
+func (c *apiKeyController) GetAllAPIKeys(ctx *gin.Context) {
+	apiKeys, err := c.Service.GetAllAPIKeys(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, apiKeys)
+}
+
+func (c *apiKeyController) DeleteAPIKey(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if err := c.Service.DeleteAPIKey(ctx, uint(id)); err != nil {
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "API key revoked successfully"})
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type authController struct {
+	Service services.AuthService
+}
+
+type AuthController interface {
+	Login(ctx *gin.Context)
+	Refresh(ctx *gin.Context)
+}
+
+func NewAuthController(service services.AuthService) *authController {
+	return &authController{Service: service}
+}
+
+func (c *authController) Login(ctx *gin.Context) {
+	var login models.LoginRequest
+	if err := ctx.ShouldBindJSON(&login); err != nil {
+		reason := utils.HandleUnmarshalTypeError(err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": reason})
+		return
+	}
+
+	// Validate login fields
+	validationErrors := utils.ValidateStruct(login)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	tokens, err := c.Service.Login(ctx, login.Email, login.Password)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidCredentials) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+func (c *authController) Refresh(ctx *gin.Context) {
+	var refresh models.RefreshRequest
+	if err := ctx.ShouldBindJSON(&refresh); err != nil {
+		reason := utils.HandleUnmarshalTypeError(err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": reason})
+		return
+	}
+
+	// Validate refresh fields
+	validationErrors := utils.ValidateStruct(refresh)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	tokens, err := c.Service.Refresh(ctx, refresh.RefreshToken)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidToken) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tokens)
+}
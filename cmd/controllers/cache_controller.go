@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type cacheController struct {
+	Service services.CacheService
+}
+
+type CacheController interface {
+	PurgeCache(ctx *gin.Context)
+}
+
+func NewCacheController(service services.CacheService) *cacheController {
+	return &cacheController{Service: service}
+}
+
+func (c *cacheController) PurgeCache(ctx *gin.Context) {
+	if err := c.Service.PurgeReports(ctx, ctx.Query("tag")); err != nil {
+		if errors.Is(err, models.ErrUnknownCacheTag) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Cache purged successfully"})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -19,6 +20,10 @@ type CategoryController interface {
 	CreateCategory(ctx *gin.Context)
 	GetAllCategories(ctx *gin.Context)
 	GetCategoryByID(ctx *gin.Context)
+	GetCategoryTree(ctx *gin.Context)
+	UpdateCategory(ctx *gin.Context)
+	DeleteCategory(ctx *gin.Context)
+	RestoreCategory(ctx *gin.Context)
 }
 
 func NewCategoryController(service services.CategoryService) *categoryController {
@@ -33,6 +38,11 @@ func (c *categoryController) CreateCategory(ctx *gin.Context) {
 		return
 	}
 
+	// A parent_id of 0 creates a root category
+	if category.ParentID != nil && *category.ParentID == 0 {
+		category.ParentID = nil
+	}
+
 	// Validate category fields
 	validationErrors := utils.ValidateStruct(category)
 	if validationErrors != nil {
@@ -40,7 +50,11 @@ func (c *categoryController) CreateCategory(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.Service.CreateCategory(&category); err != nil {
+	if err := c.Service.CreateCategory(ctx, &category); err != nil {
+		if errors.Is(err, models.ErrCategoryNotFound) || errors.Is(err, models.ErrCategoryCycle) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -49,7 +63,15 @@ func (c *categoryController) CreateCategory(ctx *gin.Context) {
 }
 
 func (c *categoryController) GetAllCategories(ctx *gin.Context) {
-	categories, err := c.Service.GetAllCategories()
+	var (
+		categories []models.Category
+		err        error
+	)
+	if ctx.Query("include_deleted") == "true" {
+		categories, err = c.Service.GetAllCategoriesIncludingDeleted(ctx)
+	} else {
+		categories, err = c.Service.GetAllCategories(ctx)
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -59,8 +81,127 @@ func (c *categoryController) GetAllCategories(ctx *gin.Context) {
 
 func (c *categoryController) GetCategoryByID(ctx *gin.Context) {
 	id, _ := strconv.Atoi(ctx.Param("id"))
-	category, err := c.Service.GetCategoryByID(uint(id))
+	category, err := c.Service.GetCategoryByID(ctx, uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, category)
+}
+
+func (c *categoryController) GetCategoryTree(ctx *gin.Context) {
+	tree, err := c.Service.GetCategoryTree(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, tree)
+}
+
+func (c *categoryController) UpdateCategory(ctx *gin.Context) {
+	var updateCategory models.Category
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if err := ctx.ShouldBindJSON(&updateCategory); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := c.Service.GetCategoryByID(ctx, uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	// update only specific category fields, to prevent accidental changes to other fields
+	if updateCategory.Name != "" {
+		category.Name = updateCategory.Name
+	}
+	if updateCategory.Description != "" {
+		category.Description = updateCategory.Description
+	}
+	// A parent_id of 0 moves the category to the root
+	if updateCategory.ParentID != nil {
+		if *updateCategory.ParentID == 0 {
+			category.ParentID = nil
+		} else {
+			category.ParentID = updateCategory.ParentID
+		}
+	}
+
+	// Validate category fields
+	validationErrors := utils.ValidateStruct(category)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err := c.Service.UpdateCategory(ctx, &category); err != nil {
+		if errors.Is(err, models.ErrCategoryNotFound) || errors.Is(err, models.ErrCategoryCycle) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+func (c *categoryController) DeleteCategory(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	reassignTo := ctx.Query("reassign_to")
+	cascade := ctx.Query("cascade")
+
+	if reassignTo != "" && cascade != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "reassign_to and cascade cannot be used together"})
+		return
+	}
+	if cascade != "" && cascade != "deactivate" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "cascade only supports deactivate"})
+		return
+	}
+	targetID, err := strconv.Atoi(reassignTo)
+	if reassignTo != "" && (err != nil || targetID <= 0 || targetID == id) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "reassign_to must be the ID of another category"})
+		return
+	}
+
+	if _, err := c.Service.GetCategoryByID(ctx, uint(id)); err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch {
+	case reassignTo != "":
+		err = c.Service.ReassignProductsAndDeleteCategory(ctx, uint(id), uint(targetID))
+	case cascade == "deactivate":
+		err = c.Service.DeactivateProductsAndDeleteCategory(ctx, uint(id))
+	default:
+		err = c.Service.DeleteCategory(ctx, uint(id))
+	}
+	if err != nil {
+		if errors.Is(err, models.ErrCategoryInUse) || errors.Is(err, models.ErrCategoryHasChildren) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, models.ErrCategoryNotFound) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+}
+
+func (c *categoryController) RestoreCategory(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	category, err := c.Service.RestoreCategory(ctx, uint(id))
 	if err != nil {
+		if errors.Is(err, models.ErrCategoryParentGone) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error number of a duplicate entry in a unique index
+const mysqlDuplicateEntry = 1062
+
+type customerController struct {
+	Service services.CustomerService
+}
+
+type CustomerController interface {
+	CreateCustomer(ctx *gin.Context)
+	GetAllCustomersWithPagination(ctx *gin.Context)
+	GetCustomerByID(ctx *gin.Context)
+	UpdateCustomer(ctx *gin.Context)
+	DeleteCustomer(ctx *gin.Context)
+	GetCustomerOrders(ctx *gin.Context)
+}
+
+func NewCustomerController(service services.CustomerService) *customerController {
+	return &customerController{Service: service}
+}
+
+func (c *customerController) CreateCustomer(ctx *gin.Context) {
+	var customer models.Customer
+	if err := ctx.ShouldBindJSON(&customer); err != nil {
+		reason := utils.HandleUnmarshalTypeError(err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": reason})
+		return
+	}
+
+	// Validate customer fields
+	validationErrors := utils.ValidateStruct(customer)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err := c.Service.CreateCustomer(ctx, &customer); err != nil {
+		writeCustomerWriteError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, customer)
+}
+
+func (c *customerController) GetAllCustomersWithPagination(ctx *gin.Context) {
+	customersWithPagination, err := c.Service.GetAllCustomersWithPagination(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, customersWithPagination)
+}
+
+func (c *customerController) GetCustomerByID(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	customer, err := c.Service.GetCustomerByID(ctx, uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, customer)
+}
+
+func (c *customerController) UpdateCustomer(ctx *gin.Context) {
+	var updateCustomer models.Customer
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if err := ctx.ShouldBindJSON(&updateCustomer); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := c.Service.GetCustomerByID(ctx, uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	// update only specific customer fields, to prevent accidental changes to other fields
+	if updateCustomer.Name != "" {
+		customer.Name = updateCustomer.Name
+	}
+	if updateCustomer.Email != "" {
+		customer.Email = updateCustomer.Email
+	}
+
+	// Validate customer fields
+	validationErrors := utils.ValidateStruct(customer)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	updatedCustomer, err := c.Service.UpdateCustomer(ctx, &customer)
+	if err != nil {
+		writeCustomerWriteError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updatedCustomer)
+}
+
+func (c *customerController) DeleteCustomer(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if err := c.Service.DeleteCustomer(ctx, uint(id)); err != nil {
+		if errors.Is(err, models.ErrCustomerHasOrders) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Customer deleted successfully"})
+}
+
+func (c *customerController) GetCustomerOrders(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if _, err := c.Service.GetCustomerByID(ctx, uint(id)); err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ordersWithPagination, err := c.Service.GetCustomerOrdersWithPagination(ctx, uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, ordersWithPagination)
+}
+
+// writeCustomerWriteError responds with a conflict when the email belongs to another customer, as
+// checked by the service or, for concurrent writes, caught by the unique index on customers.email
+func writeCustomerWriteError(ctx *gin.Context, err error) {
+	var mysqlErr *mysql.MySQLError
+	if errors.Is(err, models.ErrEmailTaken) || (errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": models.ErrEmailTaken.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
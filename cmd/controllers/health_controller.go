@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type healthController struct {
+	Service services.HealthService
+}
+
+type HealthController interface {
+	Liveness(ctx *gin.Context)
+	Readiness(ctx *gin.Context)
+}
+
+func NewHealthController(service services.HealthService) *healthController {
+	return &healthController{Service: service}
+}
+
+// Liveness only tells the process is serving requests, dependencies are left to Readiness
+func (c *healthController) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": models.HealthStatusUp})
+}
+
+func (c *healthController) Readiness(ctx *gin.Context) {
+	report := c.Service.Readiness(ctx)
+	if !report.IsUp() {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type orderController struct {
+	Service services.OrderService
+}
+
+type OrderController interface {
+	CreateOrder(ctx *gin.Context)
+	GetAllOrdersWithPagination(ctx *gin.Context)
+	GetOrderByID(ctx *gin.Context)
+}
+
+func NewOrderController(service services.OrderService) *orderController {
+	return &orderController{Service: service}
+}
+
+func (c *orderController) CreateOrder(ctx *gin.Context) {
+	var order models.Order
+	if err := ctx.ShouldBindJSON(&order); err != nil {
+		reason := utils.HandleUnmarshalTypeError(err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": reason})
+		return
+	}
+
+	// Validate order fields
+	validationErrors := utils.ValidateStruct(order)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err := c.Service.CreateOrder(ctx, &order); err != nil {
+		var stockErr *models.InsufficientStockError
+		if errors.As(err, &stockErr) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": stockErr.Error(), "shortages": stockErr.Shortages})
+			return
+		}
+		if errors.Is(err, models.ErrCustomerNotFound) ||
+			errors.Is(err, models.ErrProductNotFound) ||
+			errors.Is(err, models.ErrProductUnavailable) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, order)
+}
+
+func (c *orderController) GetAllOrdersWithPagination(ctx *gin.Context) {
+	ordersWithPagination, err := c.Service.GetAllOrdersWithPagination(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, ordersWithPagination)
+}
+
+func (c *orderController) GetOrderByID(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	order, err := c.Service.GetOrderByID(ctx, uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, order)
+}
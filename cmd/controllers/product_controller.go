@@ -22,6 +22,7 @@ type ProductController interface {
 	GetProductByID(ctx *gin.Context)
 	UpdateProduct(ctx *gin.Context)
 	DeleteProduct(ctx *gin.Context)
+	RestoreProduct(ctx *gin.Context)
 }
 
 func NewProductController(service services.ProductService) *productController {
@@ -43,7 +44,7 @@ func (c *productController) CreateProduct(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.Service.CreateProduct(&product); err != nil {
+	if err := c.Service.CreateProduct(ctx, &product); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -52,7 +53,7 @@ func (c *productController) CreateProduct(ctx *gin.Context) {
 }
 
 func (c *productController) GetAllProducts(ctx *gin.Context) {
-	products, err := c.Service.GetAllProducts()
+	products, err := c.Service.GetAllProducts(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -71,7 +72,7 @@ func (c *productController) GetAllProductsWithPagination(ctx *gin.Context) {
 
 func (c *productController) GetProductByID(ctx *gin.Context) {
 	id, _ := strconv.Atoi(ctx.Param("id"))
-	product, err := c.Service.GetProductByID(uint(id))
+	product, err := c.Service.GetProductByID(ctx, uint(id))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -87,7 +88,7 @@ func (c *productController) UpdateProduct(ctx *gin.Context) {
 		return
 	}
 
-	product, err := c.Service.GetProductByID(uint(id))
+	product, err := c.Service.GetProductByID(ctx, uint(id))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -119,7 +120,7 @@ func (c *productController) UpdateProduct(ctx *gin.Context) {
 		return
 	}
 
-	updatedProduct, err := c.Service.UpdateProduct(&product)
+	updatedProduct, err := c.Service.UpdateProduct(ctx, &product)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -130,10 +131,20 @@ func (c *productController) UpdateProduct(ctx *gin.Context) {
 
 func (c *productController) DeleteProduct(ctx *gin.Context) {
 	id, _ := strconv.Atoi(ctx.Param("id"))
-	if err := c.Service.DeleteProduct(uint(id)); err != nil {
+	if err := c.Service.DeleteProduct(ctx, uint(id)); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Product deleted successfully"})
 }
+
+func (c *productController) RestoreProduct(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	product, err := c.Service.RestoreProduct(ctx, uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, product)
+}
@@ -1,19 +1,93 @@
 package controllers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/ndkode/elabram-backend-recruitment/cmd/export"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/tracing"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Name of the report cache in the Cache-Status header
+const reportCacheName = "elabram-reports"
+
+// Status of the responses to clients that went away before their report was ready, as nginx logs them
+const statusClientClosedRequest = 499
+
+// Strategies the product report can be generated with
+var reportStrategies = map[string]bool{
+	models.ReportStrategySequential:  true,
+	models.ReportStrategyGoroutines:  true,
+	models.ReportStrategySingleQuery: true,
+}
+
+// How long the export of a report may take to write, in place of the write timeout of the server
+const reportExportWriteTimeout = 10 * time.Minute
+
+// Most rows of the exports built in memory or in a temporary file before they are sent, CSV exports
+// are streamed and not limited
+var reportExportMaxRows = map[string]int{
+	export.FormatXLSX: 100000,
+	export.FormatPDF:  10000,
+}
+
+// Seconds after which a client turned away by the limit of concurrent exports should retry
+const reportExportRetryAfter = "10"
+
+// Columns of the exported product report
+var productExportColumns = []export.Column{
+	{Title: "ID", Width: 10},
+	{Title: "Name", Width: 40},
+	{Title: "Category", Width: 25},
+	{Title: "Price", Width: 12},
+	{Title: "Stock Quantity", Width: 15},
+}
+
+// Intervals the sales time series can be bucketed by
+var timeseriesIntervals = map[string]bool{
+	"day":   true,
+	"week":  true,
+	"month": true,
+}
+
+// Size of the top customers report, the limits out of range fall back to the default
+const (
+	defaultTopCustomersLimit = 10
+	maxTopCustomersLimit     = 100
+)
+
+// Largest page of the paginated reports, every page size being cached separately
+const maxReportPageSize = 100
+
+// Columns the product report can be sorted by
+var reportSortColumns = map[string]bool{
+	"name":           true,
+	"category_id":    true,
+	"price":          true,
+	"stock_quantity": true,
+}
+
 type reportController struct {
 	Service services.ReportService
 }
 
 type ReportController interface {
 	GetProductReport(ctx *gin.Context)
+	GetTopCustomersReport(ctx *gin.Context)
+	GetProductSalesReport(ctx *gin.Context)
+	GetSalesTimeseriesReport(ctx *gin.Context)
 }
 
 func NewReportController(service services.ReportService) *reportController {
@@ -21,13 +95,376 @@ func NewReportController(service services.ReportService) *reportController {
 }
 
 func (c *reportController) GetProductReport(ctx *gin.Context) {
-	isOptimized := ctx.DefaultQuery("is_optimized", "false") == "true"
+	end := tracing.StartRequestSpan(ctx, "ReportController.GetProductReport")
+	defer end(nil)
+
+	query, err := parseReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// The format may come from the Accept header, added to the Vary of gzip
+	ctx.Writer.Header().Add("Vary", "Accept")
+	format, err := negotiateReportFormat(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if format != "json" {
+		c.exportProductReport(ctx, query, format)
+		return
+	}
 	// Generate the report
-	report, err := c.Service.GenerateProductReport(ctx, isOptimized)
+	report, cacheStatus, err := c.Service.GenerateProductReport(ctx, query)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeReportError(ctx, err)
 		return
 	}
 	// Return the report
+	setCacheHeaders(ctx, cacheStatus)
+	ctx.JSON(http.StatusOK, report)
+}
+
+// exportProductReport streams every product of the report, without pagination, as a file of format
+func (c *reportController) exportProductReport(ctx *gin.Context, query models.ReportQuery, format string) {
+	writer, err := export.NewWriter(format, ctx.Writer, "Product report", productExportColumns)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	// Large exports outlast the write timeout of the server, which is only meant for the JSON responses
+	if err := middlewares.GetResponseController(ctx).SetWriteDeadline(time.Now().Add(reportExportWriteTimeout)); err != nil {
+		logging.FromContext(ctx).Debug("could not extend the write deadline of the export", "error", err)
+	}
+	ctx.Header("Content-Type", export.ContentTypes[format])
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.Filename("product-report", format)}))
+
+	// Nothing is sent before Close in the limited formats, so going over the limit still gets a 400
+	rows, maxRows := 0, reportExportMaxRows[format]
+	err = c.Service.ExportProductReport(ctx, query, func(row models.ProductExportRow) error {
+		if rows++; maxRows > 0 && rows > maxRows {
+			return fmt.Errorf("%w: %s exports are limited to %d rows, narrow the filters or export as csv", models.ErrExportTooLarge, format, maxRows)
+		}
+		return writer.WriteRow(row.ID, row.Name, row.CategoryName, row.Price, row.StockQuantity)
+	})
+	if err == nil {
+		err = writer.Close()
+	} else {
+		writer.Discard()
+	}
+	if err == nil {
+		return
+	}
+	if !ctx.Writer.Written() {
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+		writeReportError(ctx, err)
+		return
+	}
+	// The status is already sent, the file ends early
+	logging.FromContext(ctx).Error("report export failed after it started", "format", format, "error", err)
+	ctx.Error(err)
+	ctx.Abort()
+}
+
+func (c *reportController) GetTopCustomersReport(ctx *gin.Context) {
+	end := tracing.StartRequestSpan(ctx, "ReportController.GetTopCustomersReport")
+	defer end(nil)
+
+	query, err := parseTopCustomersQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report, cacheStatus, err := c.Service.GenerateTopCustomersReport(ctx, query)
+	if err != nil {
+		writeReportError(ctx, err)
+		return
+	}
+	setCacheHeaders(ctx, cacheStatus)
+	ctx.JSON(http.StatusOK, gin.H{"customers": report})
+}
+
+func (c *reportController) GetProductSalesReport(ctx *gin.Context) {
+	end := tracing.StartRequestSpan(ctx, "ReportController.GetProductSalesReport")
+	defer end(nil)
+
+	query, err := parseProductSalesQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report, cacheStatus, err := c.Service.GenerateProductSalesReport(ctx, query)
+	if err != nil {
+		writeReportError(ctx, err)
+		return
+	}
+	setCacheHeaders(ctx, cacheStatus)
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (c *reportController) GetSalesTimeseriesReport(ctx *gin.Context) {
+	end := tracing.StartRequestSpan(ctx, "ReportController.GetSalesTimeseriesReport")
+	defer end(nil)
+
+	query, err := parseSalesTimeseriesQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report, cacheStatus, err := c.Service.GenerateSalesTimeseriesReport(ctx, query)
+	if err != nil {
+		writeReportError(ctx, err)
+		return
+	}
+	setCacheHeaders(ctx, cacheStatus)
 	ctx.JSON(http.StatusOK, report)
 }
+
+// writeReportError responds with the status matching the error of a report generation
+func writeReportError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidDateRange), errors.Is(err, models.ErrInvalidInterval), errors.Is(err, models.ErrInvalidCategory), errors.Is(err, models.ErrExportTooLarge):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrTooManyExports):
+		ctx.Header("Retry-After", reportExportRetryAfter)
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, context.Canceled):
+		// Nobody reads the response, the report is still cached for the next request
+		ctx.AbortWithStatus(statusClientClosedRequest)
+	case errors.Is(err, context.DeadlineExceeded):
+		ctx.JSON(http.StatusGatewayTimeout, gin.H{"error": "report generation timed out"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// negotiateReportFormat returns the format of the product report, json or one of the export formats,
+// from the format query parameter or else from the Accept header. Types the report is not available
+// in are answered with JSON, like before the exports
+func negotiateReportFormat(ctx *gin.Context) (string, error) {
+	if format := ctx.Query("format"); format != "" {
+		if format != "json" && export.ContentTypes[format] == "" {
+			return "", fmt.Errorf("%w: format must be one of json, csv, xlsx or pdf", models.ErrInvalidReportQuery)
+		}
+		return format, nil
+	}
+	switch ctx.NegotiateFormat(gin.MIMEJSON, export.ContentTypes[export.FormatCSV], export.ContentTypes[export.FormatXLSX], export.ContentTypes[export.FormatPDF]) {
+	case export.ContentTypes[export.FormatCSV]:
+		return export.FormatCSV, nil
+	case export.ContentTypes[export.FormatXLSX]:
+		return export.FormatXLSX, nil
+	case export.ContentTypes[export.FormatPDF]:
+		return export.FormatPDF, nil
+	}
+	return "json", nil
+}
+
+// parseReportQuery reads the filters, sorting and pagination of the product report, the invalid
+// values are rejected with models.ErrInvalidReportQuery
+func parseReportQuery(ctx *gin.Context) (models.ReportQuery, error) {
+	query := models.ReportQuery{
+		Name:               ctx.Query("name"),
+		IncludeDescendants: ctx.Query("include_descendants") == "true",
+		SortBy:             ctx.DefaultQuery("sort_by", "name"),
+		SortOrder:          ctx.DefaultQuery("sort_order", "asc"),
+		Page:               1,
+		PageSize:           10,
+		Strategy:           ctx.Query("strategy"),
+	}
+	// is_optimized=true predates the strategies and selects the concurrent queries
+	if query.Strategy == "" {
+		query.Strategy = models.ReportStrategySequential
+		if ctx.Query("is_optimized") == "true" {
+			query.Strategy = models.ReportStrategyGoroutines
+		}
+	}
+	if !reportStrategies[query.Strategy] {
+		return query, fmt.Errorf("%w: strategy must be one of sequential, goroutines or single_query", models.ErrInvalidReportQuery)
+	}
+	if !reportSortColumns[query.SortBy] {
+		return query, fmt.Errorf("%w: sort_by must be one of name, category_id, price or stock_quantity", models.ErrInvalidReportQuery)
+	}
+	if query.SortOrder != "asc" && query.SortOrder != "desc" {
+		return query, fmt.Errorf("%w: sort_order must be asc or desc", models.ErrInvalidReportQuery)
+	}
+
+	categoryID, err := parseIntQuery(ctx, "category_id", 1)
+	if err != nil {
+		return query, err
+	}
+	if categoryID != nil {
+		query.CategoryID = uint(*categoryID)
+	}
+	if err := parseReportPage(ctx, &query.Page, &query.PageSize); err != nil {
+		return query, err
+	}
+	if query.MinStock, err = parseIntQuery(ctx, "min_stock", 0); err != nil {
+		return query, err
+	}
+	if query.MaxStock, err = parseIntQuery(ctx, "max_stock", 0); err != nil {
+		return query, err
+	}
+	if query.MinPrice, err = parsePriceQuery(ctx, "min_price"); err != nil {
+		return query, err
+	}
+	if query.MaxPrice, err = parsePriceQuery(ctx, "max_price"); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// parseTopCustomersQuery reads the date range and the size of the top customers report
+func parseTopCustomersQuery(ctx *gin.Context) (models.TopCustomersQuery, error) {
+	query := models.TopCustomersQuery{Limit: defaultTopCustomersLimit}
+	var err error
+	if query.From, query.To, err = parseDateRange(ctx); err != nil {
+		return query, err
+	}
+	if limit, err := strconv.Atoi(ctx.Query("limit")); err == nil && limit > 0 && limit <= maxTopCustomersLimit {
+		query.Limit = limit
+	}
+	return query, nil
+}
+
+// parseProductSalesQuery reads the date range, filters and pagination of the product sales report
+func parseProductSalesQuery(ctx *gin.Context) (models.ProductSalesQuery, error) {
+	query := models.ProductSalesQuery{
+		Name:               ctx.Query("name"),
+		IncludeDescendants: ctx.Query("include_descendants") == "true",
+		Page:               1,
+		PageSize:           10,
+	}
+	var err error
+	if query.From, query.To, err = parseDateRange(ctx); err != nil {
+		return query, err
+	}
+	if query.CategoryID, err = parseCategoryID(ctx); err != nil {
+		return query, err
+	}
+	if err := parseReportPage(ctx, &query.Page, &query.PageSize); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// parseSalesTimeseriesQuery reads the date range, interval and category of the sales time series
+func parseSalesTimeseriesQuery(ctx *gin.Context) (models.SalesTimeseriesQuery, error) {
+	query := models.SalesTimeseriesQuery{
+		Interval:           ctx.DefaultQuery("interval", "day"),
+		IncludeDescendants: ctx.Query("include_descendants") == "true",
+	}
+	if !timeseriesIntervals[query.Interval] {
+		return query, fmt.Errorf("%w: interval must be one of day, week or month", models.ErrInvalidInterval)
+	}
+	var err error
+	if query.From, query.To, err = parseDateRange(ctx); err != nil {
+		return query, err
+	}
+	if query.CategoryID, err = parseCategoryID(ctx); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// parseDateRange parses the from and to dates (YYYY-MM-DD, both inclusive) of the sales reports.
+// The returned upper bound is exclusive, set to the start of the day after to
+func parseDateRange(ctx *gin.Context) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+	if value := ctx.Query("from"); value != "" {
+		date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: from must be formatted as YYYY-MM-DD", models.ErrInvalidDateRange)
+		}
+		from = &date
+	}
+	if value := ctx.Query("to"); value != "" {
+		date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: to must be formatted as YYYY-MM-DD", models.ErrInvalidDateRange)
+		}
+		date = date.AddDate(0, 0, 1)
+		to = &date
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, fmt.Errorf("%w: from must not be after to", models.ErrInvalidDateRange)
+	}
+	return from, to, nil
+}
+
+// parseCategoryID parses the optional category_id of the sales reports, zero when it is not set
+func parseCategoryID(ctx *gin.Context) (uint, error) {
+	value := ctx.Query("category_id")
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: category_id must be a positive integer", models.ErrInvalidCategory)
+	}
+	return uint(id), nil
+}
+
+// parseReportPage reads the page and page_size of a paginated report into page and pageSize, which
+// keep their defaults when the parameters are not set
+func parseReportPage(ctx *gin.Context, page, pageSize *int) error {
+	value, err := parseIntQuery(ctx, "page", 1)
+	if err != nil {
+		return err
+	}
+	if value != nil {
+		*page = *value
+	}
+	if value, err = parseIntQuery(ctx, "page_size", 1); err != nil {
+		return err
+	}
+	if value != nil {
+		if *value > maxReportPageSize {
+			return fmt.Errorf("%w: page_size must be at most %d", models.ErrInvalidReportQuery, maxReportPageSize)
+		}
+		*pageSize = *value
+	}
+	return nil
+}
+
+// parseIntQuery parses an optional integer query parameter of at least min
+func parseIntQuery(ctx *gin.Context, name string, min int) (*int, error) {
+	value := ctx.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < min {
+		return nil, fmt.Errorf("%w: %s must be an integer of at least %d", models.ErrInvalidReportQuery, name, min)
+	}
+	return &number, nil
+}
+
+// parsePriceQuery parses an optional price query parameter
+func parsePriceQuery(ctx *gin.Context, name string) (*float64, error) {
+	value := ctx.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return nil, fmt.Errorf("%w: %s must be a positive number", models.ErrInvalidReportQuery, name)
+	}
+	return &price, nil
+}
+
+// setCacheHeaders tells the client whether the report comes from the cache (RFC 9211) and, if so,
+// how many seconds ago it was generated and for how many more it is fresh, negative when it is stale.
+// Generated reports are marked collapsed when they were shared with other requests
+func setCacheHeaders(ctx *gin.Context, status models.ReportCacheStatus) {
+	if !status.Hit {
+		cacheStatus := reportCacheName + "; fwd=miss"
+		if status.Collapsed {
+			cacheStatus += "; collapsed"
+		}
+		ctx.Header("Cache-Status", cacheStatus)
+		return
+	}
+	ctx.Header("Cache-Status", fmt.Sprintf("%s; hit; ttl=%d", reportCacheName, int(status.TTL.Seconds())))
+	ctx.Header("Age", strconv.Itoa(int(status.Age.Seconds())))
+}
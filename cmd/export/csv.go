@@ -0,0 +1,46 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// First characters of the cells spreadsheets read as formulas
+const formulaPrefixes = "=+-@\t\r"
+
+// csvWriter writes the rows as they come, through the buffer of the csv.Writer
+type csvWriter struct {
+	writer *csv.Writer
+	values []string
+}
+
+func newCSVWriter(w io.Writer, columns []Column) (*csvWriter, error) {
+	writer := &csvWriter{writer: csv.NewWriter(w), values: make([]string, len(columns))}
+	for i, column := range columns {
+		writer.values[i] = column.Title
+	}
+	if err := writer.writer.Write(writer.values); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvWriter) WriteRow(values ...any) error {
+	for i, value := range values {
+		w.values[i] = formatValue(value)
+		// Text starting like a formula is quoted so spreadsheets do not evaluate it
+		if text, ok := value.(string); ok && text != "" && strings.IndexByte(formulaPrefixes, text[0]) >= 0 {
+			w.values[i] = "'" + text
+		}
+	}
+	return w.writer.Write(w.values[:len(values)])
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// Discard leaves the rows already written, the CSV ends where it stopped
+func (w *csvWriter) Discard() {}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Formats the reports can be exported in
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatPDF  = "pdf"
+)
+
+// Content types of the export formats
+var ContentTypes = map[string]string{
+	FormatCSV:  "text/csv",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatPDF:  "application/pdf",
+}
+
+// Column of an exported table. Width is in characters, the PDF columns share the width of the page
+// in proportion to it
+type Column struct {
+	Title string
+	Width float64
+}
+
+// Writer writes the rows of a table in a file format. Close ends the file once every row is
+// written, the XLSX and PDF files are only written to the output then. Discard drops an incomplete
+// file instead
+type Writer interface {
+	WriteRow(values ...any) error
+	Close() error
+	Discard()
+}
+
+// NewWriter returns the Writer of format, writing to w a table of columns titled title
+func NewWriter(format string, w io.Writer, title string, columns []Column) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatXLSX:
+		return newXLSXWriter(w, title, columns)
+	case FormatPDF:
+		return newPDFWriter(w, title, columns), nil
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// Filename returns the name of a file exported today in format, e.g. product-report-2024-05-01.csv
+func Filename(name, format string) string {
+	return name + "-" + time.Now().Format(time.DateOnly) + "." + format
+}
+
+// formatValue formats the value of a cell as text, floats without exponent or trailing zeros
+func formatValue(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
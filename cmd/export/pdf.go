@@ -0,0 +1,96 @@
+package export
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Height of the rows of the PDF tables, in millimeters
+const pdfRowHeight = 6
+
+// pdfWriter lays the rows out in a table on landscape A4 pages, repeating the title and the column
+// titles on every page. The document is built in memory and written to the output on Close
+type pdfWriter struct {
+	output    io.Writer
+	pdf       *gofpdf.Fpdf
+	widths    []float64
+	translate func(string) string
+}
+
+func newPDFWriter(w io.Writer, title string, columns []Column) *pdfWriter {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	writer := &pdfWriter{
+		output: w,
+		pdf:    pdf,
+		widths: make([]float64, len(columns)),
+		// The core fonts are encoded in cp1252 rather than UTF-8
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	var totalWidth float64
+	for _, column := range columns {
+		totalWidth += column.Width
+	}
+	for i, column := range columns {
+		writer.widths[i] = (pageWidth - left - right) * column.Width / totalWidth
+	}
+
+	generatedAt := time.Now().Format("2006-01-02 15:04")
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.CellFormat(0, 10, writer.translate(title), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(0, 10, "Generated "+generatedAt, "", 1, "R", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(230, 230, 230)
+		for i, column := range columns {
+			pdf.CellFormat(writer.widths[i], pdfRowHeight, writer.translate(column.Title), "1", 0, "L", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(0, 10, "Page "+strconv.Itoa(pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+	return writer
+}
+
+func (w *pdfWriter) WriteRow(values ...any) error {
+	for i, value := range values {
+		// Numbers are right aligned, text is cut to the width of its column
+		align, text := "R", formatValue(value)
+		if _, ok := value.(string); ok {
+			align, text = "L", w.fit(w.translate(text), w.widths[i])
+		}
+		w.pdf.CellFormat(w.widths[i], pdfRowHeight, text, "1", 0, align, false, 0, "")
+	}
+	w.pdf.Ln(-1)
+	return w.pdf.Error()
+}
+
+// fit shortens text with an ellipsis until it fits in width
+func (w *pdfWriter) fit(text string, width float64) string {
+	// Room for the padding of the cell
+	width -= 2 * w.pdf.GetCellMargin()
+	if w.pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && w.pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
+
+func (w *pdfWriter) Close() error {
+	return w.pdf.Output(w.output)
+}
+
+func (w *pdfWriter) Discard() {}
@@ -0,0 +1,75 @@
+package export
+
+import (
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxWriter writes the rows with the stream writer of excelize, which keeps them in a temporary
+// file past a few megabytes rather than in memory. The workbook is written to the output on Close
+type xlsxWriter struct {
+	output io.Writer
+	file   *excelize.File
+	sheet  *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer, title string, columns []Column) (*xlsxWriter, error) {
+	writer := &xlsxWriter{output: w, file: excelize.NewFile(), row: 1}
+	if err := writer.writeHeader(title, columns); err != nil {
+		writer.file.Close()
+		return nil, err
+	}
+	return writer, nil
+}
+
+// writeHeader names the sheet after the title and writes the column titles, frozen above the rows
+func (w *xlsxWriter) writeHeader(title string, columns []Column) error {
+	if err := w.file.SetSheetName(w.file.GetSheetName(0), title); err != nil {
+		return err
+	}
+	sheet, err := w.file.NewStreamWriter(title)
+	if err != nil {
+		return err
+	}
+	w.sheet = sheet
+	// The widths and panes must be set before the first row
+	titles := make([]any, len(columns))
+	for i, column := range columns {
+		if err := sheet.SetColWidth(i+1, i+1, column.Width); err != nil {
+			return err
+		}
+		titles[i] = column.Title
+	}
+	if err := sheet.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+	bold, err := w.file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	return sheet.SetRow("A1", titles, excelize.RowOpts{StyleID: bold})
+}
+
+func (w *xlsxWriter) WriteRow(values ...any) error {
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	return w.sheet.SetRow(cell, values)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.file.Write(w.output)
+}
+
+// Discard removes the temporary file of the rows
+func (w *xlsxWriter) Discard() {
+	w.file.Close()
+}
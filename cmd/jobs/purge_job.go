@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+)
+
+// StartPurgeJob runs Purge in the background, once at startup and then every interval until ctx is
+// done. The job is tracked by background until it returns
+func StartPurgeJob(ctx context.Context, background *sync.WaitGroup, productRepo repositories.ProductRepository, categoryRepo repositories.CategoryRepository, retention, interval time.Duration, logger *slog.Logger) {
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := Purge(ctx, productRepo, categoryRepo, retention, logger); err != nil {
+				logger.Error("purge of soft deleted rows failed", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Purge permanently removes products and categories soft deleted for longer than retention.
+// Products go first, so categories they were the last reference to can be purged in the same run.
+// A purge cut short by ctx is rolled back statement by statement, the rest is purged on the next run
+func Purge(ctx context.Context, productRepo repositories.ProductRepository, categoryRepo repositories.CategoryRepository, retention time.Duration, logger *slog.Logger) error {
+	deletedBefore := time.Now().Add(-retention)
+
+	purgedProducts, err := productRepo.PurgeDeletedProducts(ctx, deletedBefore)
+	if err != nil {
+		return err
+	}
+	purgedCategories, err := categoryRepo.PurgeDeletedCategories(ctx, deletedBefore)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("purged soft deleted rows", "products", purgedProducts, "categories", purgedCategories, "deleted_before", deletedBefore.Format(time.RFC3339))
+	return nil
+}
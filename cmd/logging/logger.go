@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Key of the request scoped logger in the gin context
+const loggerKey = "logger"
+
+type contextKey struct{}
+
+// New returns a JSON logger writing to w. level is one of debug, info, warn or error, defaulting to info
+func New(w io.Writer, level string) *slog.Logger {
+	var logLevel slog.Level
+	if err := logLevel.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		logLevel = slog.LevelInfo
+	}
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: logLevel}))
+}
+
+// Bind makes logger the request scoped logger, for the gin context as well as for the request
+// context, so it is found by layers that only receive a context.Context
+func Bind(ctx *gin.Context, logger *slog.Logger) {
+	ctx.Set(loggerKey, logger)
+	ctx.Request = ctx.Request.WithContext(NewContext(ctx.Request.Context(), logger))
+}
+
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the request scoped logger, or the default logger outside of a request
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx == nil {
+		return slog.Default()
+	}
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -1,24 +1,165 @@
 package main
 
 import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/configs"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/jobs"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/metrics"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/routes"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/tracing"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+const serviceName = "elabram-backend"
+
 func main() {
-	r := gin.Default()
+	// Configuration from the defaults, an optional file, the environment and the flags
+	config, err := configs.Load(os.Args[1:])
+	if err != nil {
+		slog.Error("could not load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	logger := configs.NewLogger(config.Log)
+	slog.SetDefault(logger)
+
+	// Tracing, exported as configured by OTEL_TRACES_EXPORTER
+	shutdownTracing, err := tracing.Setup(context.Background(), serviceName)
+	if err != nil {
+		panic("Failed to set up tracing: " + err.Error())
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("could not flush traces", "error", err)
+		}
+	}()
+
+	r := gin.New()
+	// Only the configured proxies may set the client IP, which requests are rate limited by
+	if err := r.SetTrustedProxies(config.Server.TrustedProxies); err != nil {
+		logger.Error("could not set trusted proxies", "error", err)
+		os.Exit(1)
+	}
+	// Let the layers below the controllers use the gin context as the request context
+	r.ContextWithFallback = true
+
+	// Request IDs, structured access logs, request metrics and traces
+	r.Use(middlewares.RequestID(logger), middlewares.Tracing(), middlewares.AccessLog(), middlewares.Metrics(), middlewares.Recovery())
+
+	// Gzip Compression, the response controller is kept first so long exports can extend their write deadline
+	r.Use(middlewares.ResponseController(), gzip.Gzip(gzip.DefaultCompression))
+
+	// Connect to Database, MySQL being down only fails readiness
+	if err := configs.ConnectDB(config.Database, logger); err != nil {
+		logger.Error("could not open database", "error", err)
+		os.Exit(1)
+	}
+	if err := metrics.InstrumentDB(configs.DB, config.Database.Name); err != nil {
+		logger.Error("could not instrument database", "error", err)
+	}
+	if err := configs.DB.Use(tracing.NewGormPlugin()); err != nil {
+		logger.Error("could not trace database", "error", err)
+	}
 
-	// Gzip Compression
-	r.Use(gzip.Gzip(gzip.DefaultCompression))
+	// Prometheus metrics
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
-	// Connect to Database
-	configs.ConnectDB()
+	// Liveness and readiness probes, like the metrics they skip authentication and rate limiting
+	sqlDB, err := configs.DB.DB()
+	if err != nil {
+		panic("Failed to get database connection pool: " + err.Error())
+	}
+	redisClient := configs.ClientRedis(config.Redis)
+	healthService := services.NewHealthService(map[string]services.HealthCheck{
+		"mysql": sqlDB.PingContext,
+		"redis": func(ctx context.Context) error { return redisClient.Ping(ctx).Err() },
+	}, config.Server.HealthCheckTimeout)
+	routes.HealthRoutes(r, controllers.NewHealthController(healthService))
 
-	// Setup Router
-	routes.SetupRouter(r)
+	// Setup Router, the purge job and the report generations left running by their requests are
+	// waited for on shutdown
+	var background sync.WaitGroup
+	routes.SetupRouter(r, config, redisClient, logger, &background)
+
+	// Stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Purge rows soft deleted for longer than the retention
+	jobs.StartPurgeJob(ctx, &background, repositories.NewProductRepository(configs.DB), repositories.NewCategoryRepository(configs.DB), config.Purge.Retention, config.Purge.Interval, logger)
 
 	// Run Server
-	r.Run(":8080")
+	server := &http.Server{
+		Addr:         config.Server.Addr(),
+		Handler:      r,
+		ReadTimeout:  config.Server.ReadTimeout,
+		WriteTimeout: config.Server.WriteTimeout,
+		IdleTimeout:  config.Server.IdleTimeout,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("server starting", "addr", server.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		logger.Error("server stopped", "error", err)
+	case <-ctx.Done():
+		// Fail readiness right away and give the orchestrator time to stop routing traffic here
+		logger.Info("shutting down", "delay", config.Server.ShutdownDelay.String(), "timeout", config.Server.ShutdownTimeout.String())
+		healthService.MarkShuttingDown()
+		time.Sleep(config.Server.ShutdownDelay)
+
+		// Stop accepting connections and drain the requests in flight
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Server.ShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error("could not drain in-flight requests", "error", err)
+		}
+	}
+
+	// Stop the purge job and let the background work finish before its connections are closed
+	stop()
+	if !waitTimeout(&background, config.Server.ShutdownTimeout) {
+		logger.Error("background work still running after the shutdown timeout")
+	}
+
+	// Release the connections once no request can use them anymore
+	if err := sqlDB.Close(); err != nil {
+		logger.Error("could not close database", "error", err)
+	}
+	if err := redisClient.Close(); err != nil {
+		logger.Error("could not close redis", "error", err)
+	}
+	logger.Info("server stopped")
+}
+
+// waitTimeout waits for wg for up to timeout, and reports whether it is done
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
 }
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "elabram"
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests, labelled by route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Number of HTTP requests being served.",
+	})
+
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of SQL statements issued through GORM.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation", "table"})
+
+	DBQueryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "Number of failed SQL statements issued through GORM.",
+	}, []string{"operation", "table"})
+
+	ReportCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "report_cache_requests_total",
+		Help:      "Number of report cache lookups, by report and result (hit, stale or miss).",
+	}, []string{"report", "result"})
+)
+
+// CacheHit, CacheStale and CacheMiss count a report cache lookup
+func CacheHit(report string) {
+	ReportCacheRequests.WithLabelValues(report, "hit").Inc()
+}
+
+func CacheStale(report string) {
+	ReportCacheRequests.WithLabelValues(report, "stale").Inc()
+}
+
+func CacheMiss(report string) {
+	ReportCacheRequests.WithLabelValues(report, "miss").Inc()
+}
@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLog writes one log line per request once it is served. It has to run after RequestID
+// to log through the request scoped logger
+func AccessLog() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		attrs := []any{
+			slog.String("method", ctx.Request.Method),
+			slog.String("route", ctx.FullPath()),
+			slog.String("path", ctx.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", ctx.Writer.Size()),
+			slog.String("client_ip", ctx.ClientIP()),
+		}
+		if user, ok := CurrentUser(ctx); ok {
+			attrs = append(attrs, slog.Uint64("user_id", uint64(user.ID)))
+		}
+		if apiKey, ok := CurrentAPIKey(ctx); ok {
+			attrs = append(attrs, slog.Uint64("api_key_id", uint64(apiKey.ID)))
+		}
+		if len(ctx.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", ctx.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		logging.FromContext(ctx).Log(ctx, level, "request served", attrs...)
+	}
+}
+
+// Recovery turns a panic in a handler into a 500 response and logs it with its stack trace
+func Recovery() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			if err := recover(); err != nil {
+				logging.FromContext(ctx).Error("panic recovered", slog.Any("error", err), slog.String("stack", string(debug.Stack())))
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			}
+		}()
+		ctx.Next()
+	}
+}
@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+	apiKeyKey    = "api_key"
+)
+
+// AuthenticateAPIKey resolves the X-API-Key header of a request, requests without it pass through
+func AuthenticateAPIKey(apiKeyService services.APIKeyService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(apiKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+
+		apiKey, err := apiKeyService.Authenticate(ctx, key)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Set(apiKeyKey, apiKey)
+		ctx.Next()
+	}
+}
+
+// CurrentAPIKey returns the API key authenticated for the request, if any
+func CurrentAPIKey(ctx *gin.Context) (models.APIKey, bool) {
+	value, exists := ctx.Get(apiKeyKey)
+	if !exists {
+		return models.APIKey{}, false
+	}
+	apiKey, ok := value.(models.APIKey)
+	return apiKey, ok
+}
+
+// Authorize accepts users with at least the given role and API keys holding the given scope
+func Authorize(role string, scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, hasUser := CurrentUser(ctx)
+		apiKey, hasAPIKey := CurrentAPIKey(ctx)
+		if !hasUser && !hasAPIKey {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			return
+		}
+		if (hasUser && models.HasRole(user.Role, role)) || (hasAPIKey && apiKey.HasScope(scope)) {
+			ctx.Next()
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions, " + role + " role or " + scope + " scope required"})
+	}
+}
+
+// RequireScope keeps a route public, but limits API keys to the ones holding the given scope
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		apiKey, hasAPIKey := CurrentAPIKey(ctx)
+		if _, hasUser := CurrentUser(ctx); hasAPIKey && !hasUser && !apiKey.HasScope(scope) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions, " + scope + " scope required"})
+			return
+		}
+		ctx.Next()
+	}
+}
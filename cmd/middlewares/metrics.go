@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Methods labelled as sent, any other method a client makes up is labelled other
+var metricsMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// Metrics records the duration of every request, labelled by route template rather than path
+// and by a known method, to keep the number of series bounded
+func Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := ctx.Request.Method
+		if !metricsMethods[method] {
+			method = "other"
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(method, route, strconv.Itoa(ctx.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
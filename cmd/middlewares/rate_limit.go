@@ -0,0 +1,93 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRateLimitGroup applies to route groups without a limit of their own, IPRateLimitGroup to
+// all the requests of an IP address whatever their credentials
+const (
+	DefaultRateLimitGroup = "default"
+	IPRateLimitGroup      = "ip"
+)
+
+// RateLimitIP limits all the requests of each IP address. It runs before Authenticate and
+// AuthenticateAPIKey, so the requests with invalid credentials, and the lookups they cost, are
+// limited too
+func RateLimitIP(limiter ratelimit.Limiter, limits map[string]ratelimit.Limit) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		limit, ok := limits[IPRateLimitGroup]
+		if !ok || limit.Requests <= 0 {
+			ctx.Next()
+			return
+		}
+		applyRateLimit(ctx, limiter, IPRateLimitGroup+":"+ctx.ClientIP(), limit)
+	}
+}
+
+// RateLimit limits each client per route group, the group being the first segment of the route
+// (e.g. "products" for /products/:id). Clients are identified by API key, then user, then IP,
+// so it has to run after Authenticate and AuthenticateAPIKey
+func RateLimit(limiter ratelimit.Limiter, limits map[string]ratelimit.Limit) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		group := routeGroup(ctx.FullPath())
+		limit, ok := limits[group]
+		if !ok {
+			group = DefaultRateLimitGroup
+			limit, ok = limits[group]
+		}
+		if !ok || limit.Requests <= 0 {
+			ctx.Next()
+			return
+		}
+
+		applyRateLimit(ctx, limiter, group+":"+clientIdentity(ctx), limit)
+	}
+}
+
+// applyRateLimit counts the request against key, and rejects it once the limit is reached
+func applyRateLimit(ctx *gin.Context, limiter ratelimit.Limiter, key string, limit ratelimit.Limit) {
+	result, err := limiter.Allow(ctx, key, limit)
+	if err != nil {
+		// Do not turn a limiter outage into an API outage
+		ctx.Next()
+		return
+	}
+
+	ctx.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	ctx.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	ctx.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+	if !result.Allowed {
+		ctx.Header("Retry-After", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+		ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+		return
+	}
+	ctx.Next()
+}
+
+func routeGroup(route string) string {
+	group, _, _ := strings.Cut(strings.TrimPrefix(route, "/"), "/")
+	return group
+}
+
+func clientIdentity(ctx *gin.Context) string {
+	if apiKey, ok := CurrentAPIKey(ctx); ok {
+		return "key:" + strconv.Itoa(int(apiKey.ID))
+	}
+	if user, ok := CurrentUser(ctx); ok {
+		return "user:" + strconv.Itoa(int(user.ID))
+	}
+	return "ip:" + ctx.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
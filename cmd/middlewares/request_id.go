@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	requestIDKey    = "request_id"
+)
+
+// Incoming request IDs are only propagated when they are safe to log and echo back
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID propagates the X-Request-ID of a request, or assigns a new one, and binds a logger
+// carrying it to the request so every log line of the request can be correlated
+func RequestID(logger *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+
+		ctx.Set(requestIDKey, requestID)
+		ctx.Header(RequestIDHeader, requestID)
+		logging.Bind(ctx, logger.With(slog.String("request_id", requestID)))
+		ctx.Next()
+	}
+}
+
+// GetRequestID returns the ID assigned to the request by RequestID
+func GetRequestID(ctx *gin.Context) string {
+	return ctx.GetString(requestIDKey)
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
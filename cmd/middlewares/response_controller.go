@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const responseControllerKey = "response_controller"
+
+// ResponseController keeps the controller of the response as written by the server. It has to run
+// before the middlewares wrapping the writer, like gzip, whose writers cannot be unwrapped
+func ResponseController() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(responseControllerKey, http.NewResponseController(ctx.Writer))
+		ctx.Next()
+	}
+}
+
+// GetResponseController returns the controller kept by ResponseController, or one of the current
+// writer when the middleware did not run
+func GetResponseController(ctx *gin.Context) *http.ResponseController {
+	if value, exists := ctx.Get(responseControllerKey); exists {
+		if controller, ok := value.(*http.ResponseController); ok {
+			return controller
+		}
+	}
+	return http.NewResponseController(ctx.Writer)
+}
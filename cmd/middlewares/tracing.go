@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span per request, continuing the trace of the caller when the request
+// carries a W3C traceparent header. It has to run after RequestID, the trace ID is added to the
+// request scoped logger to correlate logs with traces
+func Tracing() gin.HandlerFunc {
+	tracer := tracing.Tracer()
+	return func(ctx *gin.Context) {
+		parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		route := ctx.FullPath()
+		spanName := ctx.Request.Method + " " + route
+		if route == "" {
+			spanName = ctx.Request.Method
+		}
+		spanCtx, span := tracer.Start(parent, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+				semconv.ClientAddress(ctx.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+		if span.SpanContext().IsValid() {
+			logging.Bind(ctx, logging.FromContext(spanCtx).With(slog.String("trace_id", span.SpanContext().TraceID().String())))
+		}
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
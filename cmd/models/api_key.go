@@ -0,0 +1,45 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	ScopeProductsRead    = "products:read"
+	ScopeProductsWrite   = "products:write"
+	ScopeCategoriesRead  = "categories:read"
+	ScopeCategoriesWrite = "categories:write"
+	ScopeReportsRead     = "reports:read"
+)
+
+// APIKey authenticates a machine client, only the SHA-256 hash of the key is stored
+type APIKey struct {
+	ID        uint       `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	KeyHash   string     `json:"-"`
+	Scopes    []string   `json:"scopes" gorm:"serializer:json"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (k APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+func (k APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !k.ExpiresAt.After(now)
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,min=2,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,gt=0,dive,oneof=products:read products:write categories:read categories:write reports:read"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreatedAPIKey carries the plain key, it is only returned once when the key is created
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -1,7 +1,14 @@
 package models
 
+import (
+	"gorm.io/gorm"
+)
+
 type Category struct {
-	ID          uint   `json:"id,omitempty"`
-	Name        string `json:"name,omitempty" validate:"required,min=2,max=25"`
-	Description string `json:"description,omitempty"`
+	ID          uint           `json:"id,omitempty"`
+	Name        string         `json:"name,omitempty" validate:"required,min=2,max=25"`
+	Description string         `json:"description,omitempty"`
+	ParentID    *uint          `json:"parent_id,omitempty"`
+	Children    []Category     `json:"children,omitempty" gorm:"foreignKey:ParentID" validate:"-"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" validate:"-"`
 }
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+type Customer struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name" validate:"required,min=2,max=100"`
+	Email     string    `json:"email" validate:"required,email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CustomersPageable struct {
+	Customers  []Customer `json:"customers"`
+	Page       int        `json:"page"`
+	TotalItems int64      `json:"total_items"`
+	TotalPages int        `json:"total_pages"`
+}
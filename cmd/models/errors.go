@@ -0,0 +1,46 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrProductNotFound     = errors.New("product not found")
+	ErrProductUnavailable  = errors.New("product is not available")
+	ErrCustomerNotFound    = errors.New("customer not found")
+	ErrCustomerHasOrders   = errors.New("customer has existing orders")
+	ErrEmailTaken          = errors.New("email is already registered")
+	ErrInvalidDateRange    = errors.New("invalid date range")
+	ErrInvalidInterval     = errors.New("invalid interval")
+	ErrInvalidCategory     = errors.New("invalid category")
+	ErrInvalidReportQuery  = errors.New("invalid report query")
+	ErrExportTooLarge      = errors.New("export too large")
+	ErrTooManyExports      = errors.New("too many exports in progress, retry later")
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrCategoryInUse       = errors.New("category still has products")
+	ErrCategoryHasChildren = errors.New("category still has subcategories")
+	ErrCategoryCycle       = errors.New("category cannot be its own ancestor")
+	ErrCategoryParentGone  = errors.New("parent category is deleted, restore it first")
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrInvalidToken        = errors.New("invalid or expired token")
+	ErrInvalidAPIKey       = errors.New("invalid or expired API key")
+	ErrAPIKeyNotFound      = errors.New("API key not found")
+	ErrExpiryInPast        = errors.New("expires_at must be in the future")
+	ErrUnknownCacheTag     = errors.New("unknown cache tag")
+)
+
+type StockShortage struct {
+	ProductID uint `json:"product_id"`
+	Requested int  `json:"requested"`
+	Available int  `json:"available"`
+}
+
+// InsufficientStockError is returned when one or more order lines cannot be fulfilled
+type InsufficientStockError struct {
+	Shortages []StockShortage
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for %d product(s)", len(e.Shortages))
+}
@@ -0,0 +1,24 @@
+package models
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// DependencyHealth is the outcome of the check of a single dependency
+type DependencyHealth struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// HealthReport is the readiness of the service with the breakdown per dependency
+type HealthReport struct {
+	Status       string                      `json:"status"`
+	ShuttingDown bool                        `json:"shutting_down,omitempty"`
+	Checks       map[string]DependencyHealth `json:"checks"`
+}
+
+func (r HealthReport) IsUp() bool {
+	return r.Status == HealthStatusUp
+}
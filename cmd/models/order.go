@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+type Order struct {
+	ID         uint        `json:"id"`
+	CustomerID uint        `json:"customer_id" validate:"required"`
+	Customer   *Customer   `json:"customer,omitempty" gorm:"foreignKey:CustomerID" validate:"-"`
+	TotalPrice float64     `json:"total_price"`
+	Items      []OrderItem `json:"items" gorm:"foreignKey:OrderID" validate:"required,gt=0,dive"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type OrderItem struct {
+	ID        uint     `json:"id"`
+	OrderID   uint     `json:"order_id"`
+	ProductID uint     `json:"product_id" validate:"required"`
+	Product   *Product `json:"product,omitempty" gorm:"foreignKey:ProductID" validate:"-"`
+	Quantity  int      `json:"quantity" validate:"required,gt=0"`
+	UnitPrice float64  `json:"unit_price"`
+}
+
+type OrdersPageable struct {
+	Orders     []Order `json:"orders"`
+	Page       int     `json:"page"`
+	TotalItems int64   `json:"total_items"`
+	TotalPages int     `json:"total_pages"`
+}
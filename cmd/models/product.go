@@ -2,19 +2,22 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Product struct {
-	ID            uint      `json:"id"`
-	Name          string    `json:"name" validate:"required,min=3,max=100"`
-	Description   string    `json:"description"`
-	Price         float64   `json:"price" validate:"required,gt=0"`
-	CategoryID    uint      `json:"category_id" validate:"required"`
-	Category      *Category `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
-	StockQuantity int       `json:"stock_quantity" validate:"gt=0"`
-	IsActive      bool      `json:"is_active" validate:"required"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            uint           `json:"id"`
+	Name          string         `json:"name" validate:"required,min=3,max=100"`
+	Description   string         `json:"description"`
+	Price         float64        `json:"price" validate:"required,gt=0"`
+	CategoryID    uint           `json:"category_id" validate:"required"`
+	Category      *Category      `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	StockQuantity int            `json:"stock_quantity" validate:"gte=0"`
+	IsActive      bool           `json:"is_active" validate:"required"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at" validate:"-"`
 }
 
 type ProductsPageable struct {
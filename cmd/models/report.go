@@ -0,0 +1,130 @@
+package models
+
+import "time"
+
+// Strategies generating the product report: one query after the other, the queries run
+// concurrently, or a single query computing the aggregates and the page at once
+const (
+	ReportStrategySequential  = "sequential"
+	ReportStrategyGoroutines  = "goroutines"
+	ReportStrategySingleQuery = "single_query"
+)
+
+// ReportQuery holds the filters, sorting and pagination of the product report. Nil bounds and a
+// zero CategoryID do not filter
+type ReportQuery struct {
+	Name               string
+	CategoryID         uint
+	IncludeDescendants bool
+	MinPrice           *float64
+	MaxPrice           *float64
+	MinStock           *int
+	MaxStock           *int
+	// One of name, category_id, price or stock_quantity, and asc or desc
+	SortBy    string
+	SortOrder string
+	Page      int
+	PageSize  int
+	// One of the ReportStrategy constants
+	Strategy string
+}
+
+// TopCustomersQuery holds the date range and the size of the top customers report. To is exclusive,
+// the start of the day after the last one, and nil bounds do not filter
+type TopCustomersQuery struct {
+	From  *time.Time
+	To    *time.Time
+	Limit int
+}
+
+// ProductSalesQuery holds the date range, the filters and the pagination of the product sales report.
+// To is exclusive, nil bounds and a zero CategoryID do not filter
+type ProductSalesQuery struct {
+	From               *time.Time
+	To                 *time.Time
+	Name               string
+	CategoryID         uint
+	IncludeDescendants bool
+	Page               int
+	PageSize           int
+}
+
+// SalesTimeseriesQuery holds the date range, the bucket interval and the category of the sales time
+// series. To is exclusive, nil bounds default to the last 30 days and a zero CategoryID does not filter
+type SalesTimeseriesQuery struct {
+	From *time.Time
+	To   *time.Time
+	// One of day, week or month
+	Interval           string
+	CategoryID         uint
+	IncludeDescendants bool
+}
+
+// ProductReport summarizes the products matching the report filters, with a page of them
+type ProductReport struct {
+	TotalProducts int64     `json:"total_products"`
+	TotalStock    int64     `json:"total_stock"`
+	AvgPrice      float64   `json:"avg_price"`
+	Products      []Product `json:"products"`
+}
+
+// ProductExportRow is a product of the exported product report, with the name of its category
+type ProductExportRow struct {
+	ID            uint
+	Name          string
+	CategoryName  string
+	Price         float64
+	StockQuantity int
+}
+
+type TopCustomer struct {
+	ID          uint    `json:"id"`
+	Name        string  `json:"name"`
+	Email       string  `json:"email"`
+	TotalOrders int64   `json:"total_orders"`
+	TotalSpent  float64 `json:"total_spent"`
+}
+
+type ProductSales struct {
+	ID                uint    `json:"id"`
+	Name              string  `json:"name"`
+	Price             float64 `json:"price"`
+	StockQuantity     int     `json:"stock_quantity"`
+	CategoryName      string  `json:"category_name"`
+	TotalSoldQuantity int64   `json:"total_sold_quantity"`
+	TotalRevenue      float64 `json:"total_revenue"`
+}
+
+type ProductSalesPageable struct {
+	Products   []ProductSales `json:"products"`
+	Page       int            `json:"page"`
+	TotalItems int64          `json:"total_items"`
+	TotalPages int            `json:"total_pages"`
+}
+
+type SalesTimeseriesPoint struct {
+	Period    string  `json:"period"`
+	Revenue   float64 `json:"revenue"`
+	UnitsSold int64   `json:"units_sold"`
+	Orders    int64   `json:"orders"`
+}
+
+type SalesTimeseriesReport struct {
+	From           string                 `json:"from"`
+	To             string                 `json:"to"`
+	Interval       string                 `json:"interval"`
+	CategoryID     uint                   `json:"category_id,omitempty"`
+	TotalRevenue   float64                `json:"total_revenue"`
+	TotalUnitsSold int64                  `json:"total_units_sold"`
+	Points         []SalesTimeseriesPoint `json:"points"`
+}
+
+// ReportCacheStatus tells whether a report was served from the cache, how long ago it was generated
+// and how long it stays fresh, negative for a stale report served while it is regenerated.
+// Collapsed reports were generated once for several concurrent requests
+type ReportCacheStatus struct {
+	Hit       bool
+	Age       time.Duration
+	TTL       time.Duration
+	Collapsed bool
+}
@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit allows Requests per sliding Window
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Time until the oldest request in the window expires and frees a slot
+	ResetAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Number of keys from which the limiter starts sweeping the keys of clients gone quiet
+const memoryLimiterMinSweep = 1024
+
+// memoryLimiter is a sliding window log kept in process memory, limits are per instance
+type memoryLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*window
+	// The keys are swept once there are that many, then again once they have doubled
+	sweepAt int
+	now     func() time.Time
+}
+
+// window is the log of the requests of a key within the last period of its limit
+type window struct {
+	requests []time.Time
+	period   time.Duration
+}
+
+func NewMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{windows: map[string]*window{}, sweepAt: memoryLimiterMinSweep, now: time.Now}
+}
+
+// Len returns the number of keys the limiter keeps requests of
+func (l *memoryLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.windows)
+}
+
+func (l *memoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	windowStart := now.Add(-limit.Window)
+
+	// Drop the requests that left the window
+	var requests []time.Time
+	if w, ok := l.windows[key]; ok {
+		requests = w.requests
+	}
+	first := 0
+	for first < len(requests) && !requests[first].After(windowStart) {
+		first++
+	}
+	requests = requests[first:]
+
+	result := Result{Limit: limit.Requests}
+	if len(requests) < limit.Requests {
+		requests = append(requests, now)
+		result.Allowed = true
+		result.Remaining = limit.Requests - len(requests)
+	}
+	if len(requests) > 0 {
+		result.ResetAfter = requests[0].Add(limit.Window).Sub(now)
+	}
+
+	if len(requests) == 0 {
+		delete(l.windows, key)
+	} else {
+		l.windows[key] = &window{requests: requests, period: limit.Window}
+	}
+	if len(l.windows) >= l.sweepAt {
+		l.sweep(now)
+	}
+	return result, nil
+}
+
+// sweep drops the keys without requests left in their window. Keys are otherwise only pruned when
+// they are requested again, and clients that went away would be kept forever
+func (l *memoryLimiter) sweep(now time.Time) {
+	for key, w := range l.windows {
+		if !w.requests[len(w.requests)-1].Add(w.period).After(now) {
+			delete(l.windows, key)
+		}
+	}
+	l.sweepAt = max(memoryLimiterMinSweep, 2*len(l.windows))
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Sliding window log, each request is a member of a sorted set scored by its time in milliseconds.
+// Returns whether the request is allowed, the remaining requests and the milliseconds until reset
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	redis.call('PEXPIRE', key, window)
+	count = count + 1
+	allowed = 1
+end
+
+local reset = 0
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, limit - count, reset}
+`)
+
+type redisLimiter struct {
+	Client   *redis.Client
+	Fallback Limiter
+	Logger   *slog.Logger
+	sequence atomic.Uint64
+}
+
+// NewRedisLimiter shares the limits between instances through Redis. While Redis cannot be
+// reached the requests are limited by the fallback instead
+func NewRedisLimiter(client *redis.Client, fallback Limiter, logger *slog.Logger) *redisLimiter {
+	return &redisLimiter{Client: client, Fallback: fallback, Logger: logger}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now()
+	member := fmt.Sprintf("%d-%d", now.UnixNano(), l.sequence.Add(1))
+	values, err := slidingWindowScript.Run(ctx, l.Client, []string{"ratelimit:" + key},
+		now.UnixMilli(), limit.Window.Milliseconds(), limit.Requests, member).Int64Slice()
+	if err != nil {
+		l.Logger.WarnContext(ctx, "rate limiter falling back to memory, redis unavailable", "error", err)
+		return l.Fallback.Allow(ctx, key, limit)
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit.Requests,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
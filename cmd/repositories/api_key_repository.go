@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	DB *gorm.DB
+}
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, apiKey *models.APIKey) error
+	GetAllAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (models.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id uint) error
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *apiKeyRepository {
+	return &apiKeyRepository{DB: db}
+}
+
+func (r *apiKeyRepository) CreateAPIKey(ctx context.Context, apiKey *models.APIKey) error {
+	return r.DB.WithContext(ctx).Create(apiKey).Error
+}
+
+func (r *apiKeyRepository) GetAllAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	var apiKeys []models.APIKey
+	err := r.DB.WithContext(ctx).Order("id").Find(&apiKeys).Error
+	return apiKeys, err
+}
+
+func (r *apiKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (models.APIKey, error) {
+	var apiKey models.APIKey
+	err := r.DB.WithContext(ctx).Where("key_hash = ?", keyHash).First(&apiKey).Error
+	return apiKey, err
+}
+
+func (r *apiKeyRepository) DeleteAPIKey(ctx context.Context, id uint) error {
+	result := r.DB.WithContext(ctx).Delete(&models.APIKey{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrAPIKeyNotFound
+	}
+	return nil
+}
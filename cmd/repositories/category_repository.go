@@ -1,6 +1,11 @@
 package repositories
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 
 	"gorm.io/gorm"
@@ -11,37 +16,142 @@ type categoryRepository struct {
 }
 
 type CategoryRepository interface {
-	CreateCategory(category *models.Category) error
-	GetAllCategories() ([]models.Category, error)
-	GetCategoryByID(id uint) (models.Category, error)
-	UpdateCategory(category *models.Category) error
-	DeleteCategory(id uint) error
+	CreateCategory(ctx context.Context, category *models.Category) error
+	GetAllCategories(ctx context.Context) ([]models.Category, error)
+	GetAllCategoriesIncludingDeleted(ctx context.Context) ([]models.Category, error)
+	GetCategoryByID(ctx context.Context, id uint) (models.Category, error)
+	UpdateCategory(ctx context.Context, category *models.Category) error
+	DeleteCategory(ctx context.Context, id uint) error
+	ReassignProductsAndDeleteCategory(ctx context.Context, id uint, reassignTo uint) error
+	DeactivateProductsAndDeleteCategory(ctx context.Context, id uint) error
+	RestoreCategory(ctx context.Context, id uint) (models.Category, error)
+	PurgeDeletedCategories(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
 func NewCategoryRepository(db *gorm.DB) *categoryRepository {
 	return &categoryRepository{DB: db}
 }
 
-func (r *categoryRepository) CreateCategory(category *models.Category) error {
-	return r.DB.Create(category).Error
+func (r *categoryRepository) CreateCategory(ctx context.Context, category *models.Category) error {
+	category.DeletedAt = gorm.DeletedAt{}
+	return r.DB.WithContext(ctx).Create(category).Error
+}
+
+func (r *categoryRepository) GetAllCategories(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.DB.WithContext(ctx).Find(&categories).Error
+	return categories, err
 }
 
-func (r *categoryRepository) GetAllCategories() ([]models.Category, error) {
+func (r *categoryRepository) GetAllCategoriesIncludingDeleted(ctx context.Context) ([]models.Category, error) {
 	var categories []models.Category
-	err := r.DB.Find(&categories).Error
+	err := r.DB.WithContext(ctx).Unscoped().Find(&categories).Error
 	return categories, err
 }
 
-func (r *categoryRepository) GetCategoryByID(id uint) (models.Category, error) {
+func (r *categoryRepository) GetCategoryByID(ctx context.Context, id uint) (models.Category, error) {
 	var category models.Category
-	err := r.DB.First(&category, id).Error
+	err := r.DB.WithContext(ctx).First(&category, id).Error
 	return category, err
 }
 
-func (r *categoryRepository) UpdateCategory(category *models.Category) error {
-	return r.DB.Save(category).Error
+func (r *categoryRepository) UpdateCategory(ctx context.Context, category *models.Category) error {
+	return r.DB.WithContext(ctx).Save(category).Error
+}
+
+func (r *categoryRepository) DeleteCategory(ctx context.Context, id uint) error {
+	// Soft deleted products count too, restoring them must not bring back a dangling category
+	var totalProducts int64
+	if err := r.DB.WithContext(ctx).Unscoped().Model(&models.Product{}).Where("category_id = ?", id).Count(&totalProducts).Error; err != nil {
+		return err
+	}
+	if totalProducts > 0 {
+		return fmt.Errorf("%w: %d product(s) reference it, use ?reassign_to=<id> or ?cascade=deactivate", models.ErrCategoryInUse, totalProducts)
+	}
+	// Soft deleted subcategories count too, restoring them must not bring back a dangling parent
+	var totalChildren int64
+	if err := r.DB.WithContext(ctx).Unscoped().Model(&models.Category{}).Where("parent_id = ?", id).Count(&totalChildren).Error; err != nil {
+		return err
+	}
+	if totalChildren > 0 {
+		return fmt.Errorf("%w: %d subcategory(ies) reference it, use ?reassign_to=<id> or ?cascade=deactivate", models.ErrCategoryHasChildren, totalChildren)
+	}
+	return r.DB.WithContext(ctx).Delete(&models.Category{}, id).Error
+}
+
+func (r *categoryRepository) ReassignProductsAndDeleteCategory(ctx context.Context, id uint, reassignTo uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Category{}, reassignTo).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %d", models.ErrCategoryNotFound, reassignTo)
+			}
+			return err
+		}
+		// Soft deleted products are reassigned too, so they are restored into an existing category
+		if err := tx.Unscoped().Model(&models.Product{}).Where("category_id = ?", id).Update("category_id", reassignTo).Error; err != nil {
+			return err
+		}
+		if err := reparentChildren(tx, id); err != nil {
+			return err
+		}
+		return tx.Delete(&models.Category{}, id).Error
+	})
+}
+
+func (r *categoryRepository) DeactivateProductsAndDeleteCategory(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Products of a deleted category are deactivated, soft deleted ones included, they keep the
+		// reference so restoring the category brings them back
+		if err := tx.Unscoped().Model(&models.Product{}).Where("category_id = ?", id).Update("is_active", false).Error; err != nil {
+			return err
+		}
+		if err := reparentChildren(tx, id); err != nil {
+			return err
+		}
+		return tx.Delete(&models.Category{}, id).Error
+	})
+}
+
+func (r *categoryRepository) RestoreCategory(ctx context.Context, id uint) (models.Category, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category models.Category
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&category, id).Error; err != nil {
+			return err
+		}
+		// A category restored under a deleted parent would be missing from the tree
+		if category.ParentID != nil {
+			if err := tx.First(&models.Category{}, *category.ParentID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf("%w: %d", models.ErrCategoryParentGone, *category.ParentID)
+				}
+				return err
+			}
+		}
+		return tx.Unscoped().Model(&category).Update("deleted_at", nil).Error
+	})
+	if err != nil {
+		return models.Category{}, err
+	}
+	return r.GetCategoryByID(ctx, id)
+}
+
+func (r *categoryRepository) PurgeDeletedCategories(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	// Categories still referenced by any product or subcategory, deleted or not, are kept.
+	// The parent ids are selected through a derived table as MySQL can't read the table a DELETE targets.
+	result := r.DB.WithContext(ctx).Unscoped().
+		Where("deleted_at < ?", deletedBefore).
+		Where("NOT EXISTS (SELECT 1 FROM products p WHERE p.category_id = categories.id)").
+		Where("id NOT IN (SELECT parent_id FROM (SELECT DISTINCT parent_id FROM categories WHERE parent_id IS NOT NULL) AS parents)").
+		Delete(&models.Category{})
+	return result.RowsAffected, result.Error
 }
 
-func (r *categoryRepository) DeleteCategory(id uint) error {
-	return r.DB.Delete(&models.Category{}, id).Error
+// Function to move the subcategories of a category being deleted up to its own parent, soft deleted
+// ones included so they are restored into an existing category
+func reparentChildren(tx *gorm.DB, id uint) error {
+	var category models.Category
+	if err := tx.First(&category, id).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Model(&models.Category{}).Where("parent_id = ?", id).Update("parent_id", category.ParentID).Error
 }
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"math"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type customerRepository struct {
+	DB *gorm.DB
+}
+
+type CustomerRepository interface {
+	CreateCustomer(ctx context.Context, customer *models.Customer) error
+	GetAllCustomersWithPagination(ctx *gin.Context) (models.CustomersPageable, error)
+	GetCustomerByID(ctx context.Context, id uint) (models.Customer, error)
+	UpdateCustomer(ctx context.Context, customer *models.Customer) (models.Customer, error)
+	DeleteCustomer(ctx context.Context, id uint) error
+	IsEmailTaken(ctx context.Context, email string, excludeID uint) (bool, error)
+	GetCustomerOrdersWithPagination(ctx *gin.Context, id uint) (models.OrdersPageable, error)
+}
+
+func NewCustomerRepository(db *gorm.DB) *customerRepository {
+	return &customerRepository{DB: db}
+}
+
+func (r *customerRepository) CreateCustomer(ctx context.Context, customer *models.Customer) error {
+	return r.DB.WithContext(ctx).Create(customer).Error
+}
+
+func (r *customerRepository) GetAllCustomersWithPagination(ctx *gin.Context) (models.CustomersPageable, error) {
+	db, page, pageSize := applyPagination(ctx, r.DB.WithContext(ctx))
+
+	customersPageable := models.CustomersPageable{}
+
+	err := db.Find(&customersPageable.Customers).Error
+	r.DB.WithContext(ctx).Model(&models.Customer{}).Count(&customersPageable.TotalItems)
+	customersPageable.TotalPages = int(math.Ceil(float64(customersPageable.TotalItems) / float64(pageSize)))
+	customersPageable.Page = page
+
+	return customersPageable, err
+}
+
+func (r *customerRepository) GetCustomerByID(ctx context.Context, id uint) (models.Customer, error) {
+	var customer models.Customer
+	err := r.DB.WithContext(ctx).First(&customer, id).Error
+	return customer, err
+}
+
+func (r *customerRepository) UpdateCustomer(ctx context.Context, customer *models.Customer) (models.Customer, error) {
+	updatedCustomer := models.Customer{}
+	err := r.DB.WithContext(ctx).Where("id = ?", customer.ID).Updates(customer).First(&updatedCustomer).Error
+	return updatedCustomer, err
+}
+
+func (r *customerRepository) DeleteCustomer(ctx context.Context, id uint) error {
+	// Orders keep a reference to the customer, refuse instead of breaking the order history
+	var totalOrders int64
+	if err := r.DB.WithContext(ctx).Model(&models.Order{}).Where("customer_id = ?", id).Count(&totalOrders).Error; err != nil {
+		return err
+	}
+	if totalOrders > 0 {
+		return models.ErrCustomerHasOrders
+	}
+	return r.DB.WithContext(ctx).Delete(&models.Customer{}, id).Error
+}
+
+func (r *customerRepository) IsEmailTaken(ctx context.Context, email string, excludeID uint) (bool, error) {
+	var total int64
+	err := r.DB.WithContext(ctx).Model(&models.Customer{}).Where("email = ? AND id <> ?", email, excludeID).Count(&total).Error
+	return total > 0, err
+}
+
+func (r *customerRepository) GetCustomerOrdersWithPagination(ctx *gin.Context, id uint) (models.OrdersPageable, error) {
+	db, page, pageSize := applyPagination(ctx, r.DB.WithContext(ctx))
+
+	ordersPageable := models.OrdersPageable{}
+
+	err := db.Preload("Items.Product", unscoped).Where("customer_id = ?", id).Order("created_at DESC").Find(&ordersPageable.Orders).Error
+	r.DB.WithContext(ctx).Model(&models.Order{}).Where("customer_id = ?", id).Count(&ordersPageable.TotalItems)
+	ordersPageable.TotalPages = int(math.Ceil(float64(ordersPageable.TotalItems) / float64(pageSize)))
+	ordersPageable.Page = page
+
+	return ordersPageable, err
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type orderRepository struct {
+	DB *gorm.DB
+}
+
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, order *models.Order) error
+	GetAllOrdersWithPagination(ctx *gin.Context) (models.OrdersPageable, error)
+	GetOrderByID(ctx context.Context, id uint) (models.Order, error)
+}
+
+func NewOrderRepository(db *gorm.DB) *orderRepository {
+	return &orderRepository{DB: db}
+}
+
+func (r *orderRepository) CreateOrder(ctx context.Context, order *models.Order) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var totalCustomers int64
+		if err := tx.Model(&models.Customer{}).Where("id = ?", order.CustomerID).Count(&totalCustomers).Error; err != nil {
+			return err
+		}
+		if totalCustomers == 0 {
+			return fmt.Errorf("%w: %d", models.ErrCustomerNotFound, order.CustomerID)
+		}
+		order.Customer = nil
+
+		// Sum the requested quantity per product, the same product may appear on several lines
+		requested := map[uint]int{}
+		productIDs := []uint{}
+		for _, item := range order.Items {
+			if _, ok := requested[item.ProductID]; !ok {
+				productIDs = append(productIDs, item.ProductID)
+			}
+			requested[item.ProductID] += item.Quantity
+		}
+
+		// Lock the product rows (SELECT ... FOR UPDATE) in id order to avoid deadlocks between concurrent orders
+		var products []models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", productIDs).
+			Order("id").
+			Find(&products).Error; err != nil {
+			return err
+		}
+		productsByID := map[uint]models.Product{}
+		for _, product := range products {
+			productsByID[product.ID] = product
+		}
+
+		var shortages []models.StockShortage
+		for _, id := range productIDs {
+			product, ok := productsByID[id]
+			if !ok {
+				return fmt.Errorf("%w: %d", models.ErrProductNotFound, id)
+			}
+			if !product.IsActive {
+				return fmt.Errorf("%w: %d", models.ErrProductUnavailable, id)
+			}
+			if product.StockQuantity < requested[id] {
+				shortages = append(shortages, models.StockShortage{
+					ProductID: id,
+					Requested: requested[id],
+					Available: product.StockQuantity,
+				})
+			}
+		}
+		if len(shortages) > 0 {
+			return &models.InsufficientStockError{Shortages: shortages}
+		}
+
+		var totalPrice float64
+		for i := range order.Items {
+			item := &order.Items[i]
+			product := productsByID[item.ProductID]
+
+			// Capture the price at purchase time, so later price changes don't rewrite order history
+			item.UnitPrice = product.Price
+			item.Product = nil
+			totalPrice += product.Price * float64(item.Quantity)
+		}
+		order.TotalPrice = math.Round(totalPrice*100) / 100
+
+		for _, id := range productIDs {
+			// The stock guard in the WHERE clause keeps stock from ever going negative
+			result := tx.Model(&models.Product{}).
+				Where("id = ? AND stock_quantity >= ?", id, requested[id]).
+				UpdateColumn("stock_quantity", gorm.Expr("stock_quantity - ?", requested[id]))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return &models.InsufficientStockError{Shortages: []models.StockShortage{{
+					ProductID: id,
+					Requested: requested[id],
+					Available: productsByID[id].StockQuantity,
+				}}}
+			}
+		}
+
+		return tx.Create(order).Error
+	})
+}
+
+func (r *orderRepository) GetAllOrdersWithPagination(ctx *gin.Context) (models.OrdersPageable, error) {
+	db, page, pageSize := applyPagination(ctx, r.DB.WithContext(ctx))
+
+	ordersPageable := models.OrdersPageable{}
+
+	err := db.Preload("Items.Product", unscoped).Order("created_at DESC").Find(&ordersPageable.Orders).Error
+	r.DB.WithContext(ctx).Model(&models.Order{}).Count(&ordersPageable.TotalItems)
+	ordersPageable.TotalPages = int(math.Ceil(float64(ordersPageable.TotalItems) / float64(pageSize)))
+	ordersPageable.Page = page
+
+	return ordersPageable, err
+}
+
+func (r *orderRepository) GetOrderByID(ctx context.Context, id uint) (models.Order, error) {
+	var order models.Order
+	err := r.DB.WithContext(ctx).Preload("Items.Product", unscoped).First(&order, id).Error
+	return order, err
+}
+
+// Function to load associations regardless of soft deletion, order history keeps showing deleted products
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
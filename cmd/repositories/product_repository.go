@@ -1,8 +1,10 @@
 package repositories
 
 import (
+	"context"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 
@@ -15,56 +17,88 @@ type productRepository struct {
 }
 
 type ProductRepository interface {
-	CreateProduct(product *models.Product) error
-	GetAllProducts() ([]models.Product, error)
+	CreateProduct(ctx context.Context, product *models.Product) error
+	GetAllProducts(ctx context.Context) ([]models.Product, error)
 	GetAllProductsWithPagination(ctx *gin.Context) (models.ProductsPageable, error)
-	GetProductByID(id uint) (models.Product, error)
-	UpdateProduct(product *models.Product) (models.Product, error)
-	DeleteProduct(id uint) error
+	GetProductByID(ctx context.Context, id uint) (models.Product, error)
+	UpdateProduct(ctx context.Context, product *models.Product) (models.Product, error)
+	DeleteProduct(ctx context.Context, id uint) error
+	RestoreProduct(ctx context.Context, id uint) (models.Product, error)
+	PurgeDeletedProducts(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
 func NewProductRepository(db *gorm.DB) *productRepository {
 	return &productRepository{DB: db}
 }
 
-func (r *productRepository) CreateProduct(product *models.Product) error {
-	return r.DB.Create(product).Error
+func (r *productRepository) CreateProduct(ctx context.Context, product *models.Product) error {
+	product.DeletedAt = gorm.DeletedAt{}
+	return r.DB.WithContext(ctx).Create(product).Error
 }
 
-func (r *productRepository) GetAllProducts() ([]models.Product, error) {
+func (r *productRepository) GetAllProducts(ctx context.Context) ([]models.Product, error) {
 	var products []models.Product
-	err := r.DB.Preload("Category").Find(&products).Error
+	err := r.DB.WithContext(ctx).Preload("Category").Find(&products).Error
 	return products, err
 }
 
 func (r *productRepository) GetAllProductsWithPagination(ctx *gin.Context) (models.ProductsPageable, error) {
-	db, page, pageSize := applyPagination(ctx, r.DB)
+	db, page, pageSize := applyPagination(ctx, applyFilters(ctx, applyIncludeDeleted(ctx, r.DB.WithContext(ctx))))
 
 	productsPageable := models.ProductsPageable{}
 
 	err := db.Preload("Category").Find(&productsPageable.Products).Error
-	r.DB.Model(&models.Product{}).Count(&productsPageable.TotalItems)
+	applyFilters(ctx, applyIncludeDeleted(ctx, r.DB.WithContext(ctx))).Model(&models.Product{}).Count(&productsPageable.TotalItems)
 	productsPageable.TotalPages = int(math.Ceil(float64(productsPageable.TotalItems) / float64(pageSize)))
 	productsPageable.Page = page
 
 	return productsPageable, err
 }
 
-func (r *productRepository) GetProductByID(id uint) (models.Product, error) {
+func (r *productRepository) GetProductByID(ctx context.Context, id uint) (models.Product, error) {
 	var product models.Product
-	err := r.DB.Preload("Category").First(&product, id).Error
+	err := r.DB.WithContext(ctx).Preload("Category").First(&product, id).Error
 	return product, err
 }
 
-func (r *productRepository) UpdateProduct(product *models.Product) (models.Product, error) {
+func (r *productRepository) UpdateProduct(ctx context.Context, product *models.Product) (models.Product, error) {
 	updatedProduct := models.Product{}
 	product.Category = nil
-	err := r.DB.Where("id = ?", product.ID).Updates(&product).Preload("Category").First(&updatedProduct).Error
+	err := r.DB.WithContext(ctx).Where("id = ?", product.ID).Updates(&product).Preload("Category").First(&updatedProduct).Error
 	return updatedProduct, err
 }
 
-func (r *productRepository) DeleteProduct(id uint) error {
-	return r.DB.Delete(&models.Product{}, id).Error
+func (r *productRepository) DeleteProduct(ctx context.Context, id uint) error {
+	// Products are soft deleted, order lines and reports keep referencing them
+	return r.DB.WithContext(ctx).Delete(&models.Product{}, id).Error
+}
+
+func (r *productRepository) RestoreProduct(ctx context.Context, id uint) (models.Product, error) {
+	result := r.DB.WithContext(ctx).Unscoped().Model(&models.Product{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return models.Product{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.Product{}, gorm.ErrRecordNotFound
+	}
+	return r.GetProductByID(ctx, id)
+}
+
+func (r *productRepository) PurgeDeletedProducts(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	// Products referenced by order lines are kept, so the order history stays intact
+	result := r.DB.WithContext(ctx).Unscoped().
+		Where("deleted_at < ?", deletedBefore).
+		Where("NOT EXISTS (SELECT 1 FROM order_items oi WHERE oi.product_id = products.id)").
+		Delete(&models.Product{})
+	return result.RowsAffected, result.Error
+}
+
+// Function to include soft deleted rows when include_deleted=true
+func applyIncludeDeleted(ctx *gin.Context, db *gorm.DB) *gorm.DB {
+	if ctx.Query("include_deleted") == "true" {
+		return db.Unscoped()
+	}
+	return db
 }
 
 // Function to apply pagination based on query parameters
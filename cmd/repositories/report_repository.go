@@ -1,13 +1,19 @@
 package repositories
 
 import (
+	"context"
 	"fmt"
-	"sync"
+	"math"
+	"time"
 
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/tracing"
 
 	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type reportRepository struct {
@@ -15,113 +21,84 @@ type reportRepository struct {
 }
 
 type ReportRepository interface {
-	GenerateProductReportWithGoroutines(ctx *gin.Context) (map[string]interface{}, error)
-	GenerateProductReport(ctx *gin.Context) (map[string]interface{}, error)
+	GenerateProductReportWithGoroutines(ctx context.Context, query models.ReportQuery) (models.ProductReport, error)
+	GenerateProductReport(ctx context.Context, query models.ReportQuery) (models.ProductReport, error)
+	GenerateProductReportSingleQuery(ctx context.Context, query models.ReportQuery) (models.ProductReport, error)
+	ExportProductReport(ctx context.Context, query models.ReportQuery, write func(row models.ProductExportRow) error) error
+	GenerateTopCustomersReport(ctx context.Context, query models.TopCustomersQuery) ([]models.TopCustomer, error)
+	GenerateProductSalesReport(ctx context.Context, query models.ProductSalesQuery) (models.ProductSalesPageable, error)
+	GenerateSalesTimeseriesReport(ctx context.Context, query models.SalesTimeseriesQuery) (models.SalesTimeseriesReport, error)
 }
 
+// SQL expressions truncating an order timestamp to the start of its time series bucket
+var timeseriesBuckets = map[string]string{
+	"day":   "DATE_FORMAT(o.created_at, '%Y-%m-%d')",
+	"week":  "DATE_FORMAT(DATE_SUB(DATE(o.created_at), INTERVAL WEEKDAY(o.created_at) DAY), '%Y-%m-%d')",
+	"month": "DATE_FORMAT(o.created_at, '%Y-%m-01')",
+}
+
+// Upper bound of buckets in a single time series, to keep responses reasonably sized
+const maxTimeseriesBuckets = 1000
+
 func NewReportRepository(db *gorm.DB) *reportRepository {
 	return &reportRepository{DB: db}
 }
 
-func (r *reportRepository) GenerateProductReportWithGoroutines(ctx *gin.Context) (map[string]interface{}, error) {
-	fmt.Println("GenerateProductReportWithGoroutines")
+func (r *reportRepository) GenerateProductReportWithGoroutines(ctx context.Context, query models.ReportQuery) (report models.ProductReport, err error) {
+	logging.FromContext(ctx).Debug("generating product report", "strategy", "goroutines")
+	ctx, span := tracing.Start(ctx, "ReportRepository.GenerateProductReportWithGoroutines")
+	defer func() { tracing.End(span, err) }()
 
-	// Create channels to receive data from each goroutine
-	totalProductsChan := make(chan int)
-	totalStockChan := make(chan int)
-	avgPriceChan := make(chan float64)
-	productsChan := make(chan []models.Product)
-	errChan := make(chan error, 1) // To catch errors
-	var wg sync.WaitGroup
-	wg.Add(4)
+	var (
+		totalProducts int64
+		totalStock    int64
+		avgPrice      float64
+		products      []models.Product
+	)
+	// Every query runs in its own goroutine with its own span, the first error cancels the others
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return r.runProductReportQuery(groupCtx, "count", query, func(db *gorm.DB) error {
+			return db.Count(&totalProducts).Error
+		})
+	})
+	group.Go(func() error {
+		return r.runProductReportQuery(groupCtx, "sum_stock", query, func(db *gorm.DB) error {
+			return db.Select("COALESCE(SUM(stock_quantity), 0)").Scan(&totalStock).Error
+		})
+	})
+	group.Go(func() error {
+		return r.runProductReportQuery(groupCtx, "avg_price", query, func(db *gorm.DB) error {
+			return db.Select("COALESCE(AVG(price), 0)").Scan(&avgPrice).Error
+		})
+	})
+	group.Go(func() error {
+		return r.runProductReportQuery(groupCtx, "page", query, func(db *gorm.DB) error {
+			db = applyReportPagination(applyReportSorting(db, query), query)
+			return db.Preload("Category").Select("id, name, price, stock_quantity, category_id").Find(&products).Error
+		})
+	})
+	if err := group.Wait(); err != nil {
+		return report, err
+	}
 
-	// Query for total number of products, total stock, and average price
-	go func() {
-		defer wg.Done()
-		var totalProducts int64
-		// Apply filters
-		db := applyFilters(ctx, r.DB)
-		if err := db.Model(&models.Product{}).Count(&totalProducts).Error; err != nil {
-			errChan <- err
-			return
-		}
-		totalProductsChan <- int(totalProducts)
-	}()
-
-	go func() {
-		defer wg.Done()
-		var totalStock int64
-		// Apply filters
-		db := applyFilters(ctx, r.DB)
-		if err := db.Model(&models.Product{}).Select("COALESCE(SUM(stock_quantity), 0)").Scan(&totalStock).Error; err != nil {
-			errChan <- err
-			return
-		}
-		totalStockChan <- int(totalStock)
-	}()
-
-	go func() {
-		defer wg.Done()
-		var avgPrice float64
-		// Apply filters
-		db := applyFilters(ctx, r.DB)
-		if err := db.Model(&models.Product{}).Select("COALESCE(AVG(price), 0)").Scan(&avgPrice).Error; err != nil {
-			errChan <- err
-			return
-		}
-		avgPriceChan <- avgPrice
-	}()
+	return newProductReport(totalProducts, totalStock, avgPrice, products), nil
+}
+
+// runProductReportQuery runs one of the concurrent queries of the product report in its own span, on
+// the products matching the filters of query
+func (r *reportRepository) runProductReportQuery(ctx context.Context, name string, query models.ReportQuery, run func(db *gorm.DB) error) error {
+	spanCtx, span := tracing.Start(ctx, "ReportRepository.GenerateProductReportWithGoroutines."+name)
+	err := run(applyReportFilters(r.DB.WithContext(spanCtx).Model(&models.Product{}), query))
+	tracing.End(span, err)
+	return err
+}
+
+func (r *reportRepository) GenerateProductReport(ctx context.Context, query models.ReportQuery) (report models.ProductReport, err error) {
+	logging.FromContext(ctx).Debug("generating product report", "strategy", "sequential")
+	ctx, span := tracing.Start(ctx, "ReportRepository.GenerateProductReport")
+	defer func() { tracing.End(span, err) }()
 
-	// Get product details (with selected columns for efficiency)
-	go func() {
-		defer wg.Done()
-		var products []models.Product
-
-		// Apply pagination, sorting
-		db := applyFilters(ctx, r.DB)
-		db = applySorting(ctx, db)
-		db, _, _ = applyPagination(ctx, db)
-		if err := db.Preload("Category").Select("id, name, price, stock_quantity, category_id").Find(&products).Error; err != nil {
-			errChan <- err
-			return
-		}
-		productsChan <- products
-	}()
-
-	// Close channels once all goroutines finish
-	go func() {
-		wg.Wait()
-		close(totalProductsChan)
-		close(totalStockChan)
-		close(avgPriceChan)
-		close(productsChan)
-		close(errChan) // Close error channel
-	}()
-
-	// Check for errors
-	select {
-	case err := <-errChan:
-		return nil, err
-	default:
-		// No error, proceed with reading data from channels
-	}
-
-	// Read data from channels
-	totalProducts := <-totalProductsChan
-	totalStock := <-totalStockChan
-	avgPrice := <-avgPriceChan
-	products := <-productsChan
-
-	return map[string]interface{}{
-		"total_products": totalProducts,
-		"total_stock":    totalStock,
-		"avg_price":      avgPrice,
-		"products":       products,
-	}, nil
-}
-
-func (r *reportRepository) GenerateProductReport(ctx *gin.Context) (map[string]interface{}, error) {
-	fmt.Println("GenerateProductReport")
 	var (
 		totalProducts int64
 		totalStock    int64
@@ -129,27 +106,344 @@ func (r *reportRepository) GenerateProductReport(ctx *gin.Context) (map[string]i
 		products      []models.Product
 	)
 
-	// Apply filters, sorting
-	db := applyFilters(ctx, r.DB)
-	db = applySorting(ctx, db)
+	// A new session, so each query below starts from the filters alone
+	db := applyReportFilters(r.DB.WithContext(ctx).Model(&models.Product{}), query).Session(&gorm.Session{})
 
 	// Query for total number of products, total stock, and average price
-	db.Model(&models.Product{}).Count(&totalProducts)
-	db.Model(&models.Product{}).Select("COALESCE(SUM(stock_quantity), 0)").Scan(&totalStock)
-	db.Model(&models.Product{}).Select("COALESCE(AVG(price), 0)").Scan(&avgPrice)
-
-	// Apply pagination
-	db, _, _ = applyPagination(ctx, db)
+	if err := db.Count(&totalProducts).Error; err != nil {
+		return report, err
+	}
+	if err := db.Select("COALESCE(SUM(stock_quantity), 0)").Scan(&totalStock).Error; err != nil {
+		return report, err
+	}
+	if err := db.Select("COALESCE(AVG(price), 0)").Scan(&avgPrice).Error; err != nil {
+		return report, err
+	}
 
 	// Get product details (with selected columns for efficiency)
-	db.Preload("Category").Select("id, name, price, stock_quantity, category_id").Find(&products)
+	page := applyReportPagination(applyReportSorting(db, query), query)
+	if err := page.Preload("Category").Select("id, name, price, stock_quantity, category_id").Find(&products).Error; err != nil {
+		return report, err
+	}
+
+	return newProductReport(totalProducts, totalStock, avgPrice, products), nil
+}
+
+// productReportRow is a row of the single query product report: the aggregates, repeated on every
+// row, and a product of the page with its category. The product is null when the page is empty
+type productReportRow struct {
+	TotalProducts       int64
+	TotalStock          int64
+	AvgPrice            float64
+	ID                  *uint
+	Name                *string
+	Price               *float64
+	StockQuantity       *int
+	CategoryID          *uint
+	CategoryName        *string
+	CategoryDescription *string
+	CategoryParentID    *uint
+}
+
+// GenerateProductReportSingleQuery computes the aggregates and the page in a single round trip. The
+// aggregates come from one SELECT over the filtered products, the page is numbered with a window
+// function, ordered like the other strategies, and left joined to them so the aggregates are
+// returned even when the page is empty
+func (r *reportRepository) GenerateProductReportSingleQuery(ctx context.Context, query models.ReportQuery) (report models.ProductReport, err error) {
+	logging.FromContext(ctx).Debug("generating product report", "strategy", "single_query")
+	ctx, span := tracing.Start(ctx, "ReportRepository.GenerateProductReportSingleQuery")
+	defer func() { tracing.End(span, err) }()
+
+	filtered := applyReportFilters(r.DB.Model(&models.Product{}), query).Select("id, name, price, stock_quantity, category_id")
+	offset := (query.Page - 1) * query.PageSize
+
+	var rows []productReportRow
+	err = r.DB.WithContext(ctx).Raw(`WITH filtered AS (?),
+		totals AS (
+			SELECT COUNT(*) AS total_products, COALESCE(SUM(stock_quantity), 0) AS total_stock, COALESCE(AVG(price), 0) AS avg_price
+			FROM filtered
+		),
+		numbered AS (
+			SELECT filtered.*, ROW_NUMBER() OVER (?) AS row_num
+			FROM filtered
+		)
+		SELECT t.total_products, t.total_stock, t.avg_price,
+			p.id, p.name, p.price, p.stock_quantity, p.category_id,
+			c.name AS category_name, c.description AS category_description, c.parent_id AS category_parent_id
+		FROM totals t
+		LEFT JOIN numbered p ON p.row_num > ? AND p.row_num <= ?
+		LEFT JOIN categories c ON c.id = p.category_id AND c.deleted_at IS NULL
+		ORDER BY p.row_num`, filtered, reportOrderBy(query, ""), offset, offset+query.PageSize).Scan(&rows).Error
+	if err != nil {
+		return report, err
+	}
+	if len(rows) == 0 {
+		return newProductReport(0, 0, 0, nil), nil
+	}
+
+	// The products have the same columns as those of the other strategies, and the category is set
+	// like Preload does, only when it is not deleted
+	products := []models.Product{}
+	for _, row := range rows {
+		if row.ID == nil {
+			continue
+		}
+		product := models.Product{ID: *row.ID, Name: *row.Name, Price: *row.Price, StockQuantity: *row.StockQuantity, CategoryID: *row.CategoryID}
+		if row.CategoryName != nil {
+			product.Category = &models.Category{ID: *row.CategoryID, Name: *row.CategoryName, ParentID: row.CategoryParentID}
+			if row.CategoryDescription != nil {
+				product.Category.Description = *row.CategoryDescription
+			}
+		}
+		products = append(products, product)
+	}
+	return newProductReport(rows[0].TotalProducts, rows[0].TotalStock, rows[0].AvgPrice, products), nil
+}
+
+// ExportProductReport passes every product matching the filters of query to write, in the order of the
+// report and without pagination. The rows are read one at a time from the database, so the export
+// is not held in memory, and an error of write stops it
+func (r *reportRepository) ExportProductReport(ctx context.Context, query models.ReportQuery, write func(row models.ProductExportRow) error) (err error) {
+	ctx, span := tracing.Start(ctx, "ReportRepository.ExportProductReport")
+	defer func() { tracing.End(span, err) }()
+
+	// The filters are applied to the products alone, whose columns are shared with the categories
+	filtered := applyReportFilters(r.DB.Model(&models.Product{}), query).Select("id, name, price, stock_quantity, category_id")
+	rows, err := r.DB.WithContext(ctx).Table("(?) AS p", filtered).
+		Select("p.id, p.name, COALESCE(c.name, '') AS category_name, p.price, p.stock_quantity").
+		Joins("LEFT JOIN categories c ON c.id = p.category_id AND c.deleted_at IS NULL").
+		Order(reportOrderBy(query, "p")).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.ProductExportRow
+		if err := r.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := write(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// newProductReport lists no products as an empty array rather than null
+func newProductReport(totalProducts int64, totalStock int64, avgPrice float64, products []models.Product) models.ProductReport {
+	if products == nil {
+		products = []models.Product{}
+	}
+	return models.ProductReport{TotalProducts: totalProducts, TotalStock: totalStock, AvgPrice: avgPrice, Products: products}
+}
+
+func (r *reportRepository) GenerateTopCustomersReport(ctx context.Context, query models.TopCustomersQuery) ([]models.TopCustomer, error) {
+	// The date range is part of the join, so it narrows the orders counted rather than the customers listed
+	dateCondition, dateArgs := dateRangeCondition("o.created_at", query.From, query.To)
+
+	topCustomers := []models.TopCustomer{}
+	err := r.DB.WithContext(ctx).Table("customers c").
+		Select("c.id, c.name, c.email, COUNT(o.id) AS total_orders, COALESCE(SUM(o.total_price), 0) AS total_spent").
+		Joins("LEFT JOIN orders o ON c.id = o.customer_id"+dateCondition, dateArgs...).
+		Group("c.id").
+		Order("total_spent DESC").
+		Limit(query.Limit).
+		Scan(&topCustomers).Error
+
+	return topCustomers, err
+}
+
+func (r *reportRepository) GenerateProductSalesReport(ctx context.Context, query models.ProductSalesQuery) (models.ProductSalesPageable, error) {
+	productSalesPageable := models.ProductSalesPageable{Products: []models.ProductSales{}}
+
+	// Only order lines of orders placed within the date range are counted
+	dateCondition, dateArgs := dateRangeCondition("o.created_at", query.From, query.To)
+
+	db := applyProductSalesFilters(r.DB.WithContext(ctx).Table("products p").Where("p.deleted_at IS NULL"), query)
+	db = db.Select(`p.id, p.name, p.price, p.stock_quantity, c.name AS category_name,
+		COALESCE(SUM(oi.quantity), 0) AS total_sold_quantity,
+		COALESCE(SUM(oi.quantity * oi.unit_price), 0) AS total_revenue`).
+		Joins("LEFT JOIN categories c ON p.category_id = c.id AND c.deleted_at IS NULL").
+		Joins("LEFT JOIN (order_items oi JOIN orders o ON o.id = oi.order_id"+dateCondition+") ON p.id = oi.product_id", dateArgs...).
+		Group("p.id").
+		Order("total_sold_quantity DESC, p.id").
+		Offset((query.Page - 1) * query.PageSize).
+		Limit(query.PageSize)
+
+	if err := db.Scan(&productSalesPageable.Products).Error; err != nil {
+		return productSalesPageable, err
+	}
+	if err := applyProductSalesFilters(r.DB.WithContext(ctx).Table("products p").Where("p.deleted_at IS NULL"), query).Count(&productSalesPageable.TotalItems).Error; err != nil {
+		return productSalesPageable, err
+	}
+	productSalesPageable.TotalPages = int(math.Ceil(float64(productSalesPageable.TotalItems) / float64(query.PageSize)))
+	productSalesPageable.Page = query.Page
+
+	return productSalesPageable, nil
+}
+
+func (r *reportRepository) GenerateSalesTimeseriesReport(ctx context.Context, query models.SalesTimeseriesQuery) (models.SalesTimeseriesReport, error) {
+	report := models.SalesTimeseriesReport{Points: []models.SalesTimeseriesPoint{}}
 
-	return map[string]interface{}{
-		"total_products": totalProducts,
-		"total_stock":    totalStock,
-		"avg_price":      avgPrice,
-		"products":       products,
-	}, nil
+	interval := query.Interval
+	bucketSQL, ok := timeseriesBuckets[interval]
+	if !ok {
+		return report, fmt.Errorf("%w: interval must be one of day, week or month", models.ErrInvalidInterval)
+	}
+
+	from, to := query.From, query.To
+	// Default to the last 30 days, today included
+	if to == nil {
+		now := time.Now()
+		tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.Local)
+		to = &tomorrow
+	}
+	if from == nil {
+		monthAgo := to.AddDate(0, 0, -30)
+		from = &monthAgo
+	}
+
+	periods, err := TimeseriesPeriods(*from, *to, interval)
+	if err != nil {
+		return report, err
+	}
+
+	db := r.DB.WithContext(ctx).Table("orders o").
+		Select(bucketSQL+` AS period,
+			COALESCE(SUM(oi.quantity * oi.unit_price), 0) AS revenue,
+			COALESCE(SUM(oi.quantity), 0) AS units_sold,
+			COUNT(DISTINCT o.id) AS orders`).
+		Joins("JOIN order_items oi ON oi.order_id = o.id").
+		Where("o.created_at >= ? AND o.created_at < ?", *from, *to).
+		Group("period")
+	if query.CategoryID != 0 {
+		report.CategoryID = query.CategoryID
+		db = filterCategory(db.Joins("JOIN products p ON p.id = oi.product_id"), "p.category_id", query.CategoryID, query.IncludeDescendants)
+	}
+
+	var points []models.SalesTimeseriesPoint
+	if err := db.Scan(&points).Error; err != nil {
+		return report, err
+	}
+	report.Points = FillTimeseries(periods, points)
+	for _, point := range report.Points {
+		report.TotalRevenue += point.Revenue
+		report.TotalUnitsSold += point.UnitsSold
+	}
+
+	report.From = from.Format(time.DateOnly)
+	report.To = to.AddDate(0, 0, -1).Format(time.DateOnly)
+	report.Interval = interval
+	report.TotalRevenue = math.Round(report.TotalRevenue*100) / 100
+
+	return report, nil
+}
+
+// TimeseriesPeriods returns the start of every bucket of interval from the one of from up to to,
+// exclusive. Ranges of more than maxTimeseriesBuckets buckets are rejected
+func TimeseriesPeriods(from, to time.Time, interval string) ([]time.Time, error) {
+	var periods []time.Time
+	for period := truncateToBucket(from, interval); period.Before(to); period = nextBucket(period, interval) {
+		if len(periods) == maxTimeseriesBuckets {
+			return nil, fmt.Errorf("%w: the range spans more than %d %s buckets", models.ErrInvalidDateRange, maxTimeseriesBuckets, interval)
+		}
+		periods = append(periods, period)
+	}
+	return periods, nil
+}
+
+// FillTimeseries returns a point for every period, the periods without sales get zeros so the series
+// is continuous
+func FillTimeseries(periods []time.Time, points []models.SalesTimeseriesPoint) []models.SalesTimeseriesPoint {
+	pointsByPeriod := map[string]models.SalesTimeseriesPoint{}
+	for _, point := range points {
+		pointsByPeriod[point.Period] = point
+	}
+	filled := make([]models.SalesTimeseriesPoint, 0, len(periods))
+	for _, period := range periods {
+		key := period.Format(time.DateOnly)
+		point, ok := pointsByPeriod[key]
+		if !ok {
+			point = models.SalesTimeseriesPoint{Period: key}
+		}
+		filled = append(filled, point)
+	}
+	return filled
+}
+
+// Function to truncate a time to the start of its day, week (starting on Monday) or month bucket
+func truncateToBucket(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch interval {
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "month":
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+// Function to get the start of the bucket following the given one
+func nextBucket(t time.Time, interval string) time.Time {
+	switch interval {
+	case "week":
+		return t.AddDate(0, 0, 7)
+	case "month":
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// Function to apply the product filters of the sales report on its aliased products table
+func applyProductSalesFilters(db *gorm.DB, query models.ProductSalesQuery) *gorm.DB {
+	if query.Name != "" {
+		db = db.Where("p.name LIKE ?", "%"+query.Name+"%")
+	}
+	if query.CategoryID != 0 {
+		db = filterCategory(db, "p.category_id", query.CategoryID, query.IncludeDescendants)
+	}
+	return db
+}
+
+// Function to filter a category column by category_id, covering the whole subtree with include_descendants=true
+func applyCategoryFilter(ctx *gin.Context, db *gorm.DB, column string) *gorm.DB {
+	categoryID := ctx.Query("category_id")
+	if categoryID == "" {
+		return db
+	}
+	return filterCategory(db, column, categoryID, ctx.Query("include_descendants") == "true")
+}
+
+// Function to filter a category column by a category, or by its whole subtree
+func filterCategory(db *gorm.DB, column string, categoryID any, includeDescendants bool) *gorm.DB {
+	if !includeDescendants {
+		return db.Where(column+" = ?", categoryID)
+	}
+	// UNION (rather than UNION ALL) stops the recursion should the data ever contain a cycle
+	return db.Where(column+` IN (
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT id FROM subtree)`, categoryID)
+}
+
+// Function to build the SQL condition restricting a timestamp column to a date range
+func dateRangeCondition(column string, from, to *time.Time) (string, []interface{}) {
+	condition := ""
+	args := []interface{}{}
+	if from != nil {
+		condition += fmt.Sprintf(" AND %s >= ?", column)
+		args = append(args, *from)
+	}
+	if to != nil {
+		condition += fmt.Sprintf(" AND %s < ?", column)
+		args = append(args, *to)
+	}
+	return condition, args
 }
 
 // Function to apply filters based on query parameters
@@ -160,9 +454,7 @@ func applyFilters(ctx *gin.Context, db *gorm.DB) *gorm.DB {
 	}
 
 	// Filter by category
-	if categoryID := ctx.Query("category_id"); categoryID != "" {
-		db = db.Where("category_id = ?", categoryID)
-	}
+	db = applyCategoryFilter(ctx, db, "category_id")
 
 	// Filter by price range
 	if minPrice := ctx.Query("min_price"); minPrice != "" {
@@ -183,22 +475,45 @@ func applyFilters(ctx *gin.Context, db *gorm.DB) *gorm.DB {
 	return db
 }
 
-// Function to apply sorting based on query parameters
-func applySorting(ctx *gin.Context, db *gorm.DB) *gorm.DB {
-	// Sorting by column name and order (ascending or descending)
-	sortBy := ctx.DefaultQuery("sort_by", "name")
-	sortOrder := ctx.DefaultQuery("sort_order", "asc")
-
-	validSortColumns := map[string]bool{
-		"name":           true,
-		"category_id":    true,
-		"price":          true,
-		"stock_quantity": true,
+// Function to apply the product report filters
+func applyReportFilters(db *gorm.DB, query models.ReportQuery) *gorm.DB {
+	if query.Name != "" {
+		db = db.Where("name LIKE ?", "%"+query.Name+"%")
 	}
-
-	if validSortColumns[sortBy] {
-		db = db.Order(fmt.Sprintf("%s %s", sortBy, sortOrder))
+	if query.CategoryID != 0 {
+		db = filterCategory(db, "category_id", query.CategoryID, query.IncludeDescendants)
+	}
+	if query.MinPrice != nil {
+		db = db.Where("price >= ?", *query.MinPrice)
+	}
+	if query.MaxPrice != nil {
+		db = db.Where("price <= ?", *query.MaxPrice)
+	}
+	if query.MinStock != nil {
+		db = db.Where("stock_quantity >= ?", *query.MinStock)
+	}
+	if query.MaxStock != nil {
+		db = db.Where("stock_quantity <= ?", *query.MaxStock)
 	}
-
 	return db
 }
+
+// Function to sort the product report
+func applyReportSorting(db *gorm.DB, query models.ReportQuery) *gorm.DB {
+	return db.Order(reportOrderBy(query, ""))
+}
+
+// Function to build the order of the product report, on the columns of table when it is set. The
+// column is quoted rather than spliced into the SQL, and ties are broken by id so every strategy
+// returns the same pages
+func reportOrderBy(query models.ReportQuery, table string) clause.OrderBy {
+	return clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: clause.Column{Table: table, Name: query.SortBy}, Desc: query.SortOrder == "desc"},
+		{Column: clause.Column{Table: table, Name: "id"}},
+	}}
+}
+
+// Function to paginate the product report
+func applyReportPagination(db *gorm.DB, query models.ReportQuery) *gorm.DB {
+	return db.Offset((query.Page - 1) * query.PageSize).Limit(query.PageSize)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+
+	"gorm.io/gorm"
+)
+
+type userRepository struct {
+	DB *gorm.DB
+}
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUserByID(ctx context.Context, id uint) (models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+}
+
+func NewUserRepository(db *gorm.DB) *userRepository {
+	return &userRepository{DB: db}
+}
+
+func (r *userRepository) CreateUser(ctx context.Context, user *models.User) error {
+	return r.DB.WithContext(ctx).Create(user).Error
+}
+
+func (r *userRepository) GetUserByID(ctx context.Context, id uint) (models.User, error) {
+	var user models.User
+	err := r.DB.WithContext(ctx).First(&user, id).Error
+	return user, err
+}
+
+func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	var user models.User
+	err := r.DB.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	return user, err
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AdminRoutes(router *gin.Engine, apiKeyController controllers.APIKeyController, cacheController controllers.CacheController) {
+	adminRoutes := router.Group("/admin", middlewares.RequireRole(models.RoleAdmin))
+	{
+		adminRoutes.POST("/api-keys", apiKeyController.CreateAPIKey)
+		adminRoutes.GET("/api-keys", apiKeyController.GetAllAPIKeys)
+		adminRoutes.DELETE("/api-keys/:id", apiKeyController.DeleteAPIKey)
+		adminRoutes.POST("/cache/purge", cacheController.PurgeCache)
+	}
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AuthRoutes(router *gin.Engine, authController controllers.AuthController) {
+	authRoutes := router.Group("/auth")
+	{
+		authRoutes.POST("/login", authController.Login)
+		authRoutes.POST("/refresh", authController.Refresh)
+	}
+}
@@ -3,13 +3,19 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 )
 
 func CategoryRoutes(router *gin.Engine, categoryController controllers.CategoryController) {
 	categoryRoutes := router.Group("/categories")
 	{
-		categoryRoutes.POST("", categoryController.CreateCategory)
-		categoryRoutes.GET("", categoryController.GetAllCategories)
-		categoryRoutes.GET("/:id", categoryController.GetCategoryByID)
+		categoryRoutes.POST("", middlewares.Authorize(models.RoleEditor, models.ScopeCategoriesWrite), categoryController.CreateCategory)
+		categoryRoutes.GET("", middlewares.RequireRoleWhenQuery("include_deleted", "true", models.RoleAdmin), middlewares.RequireScope(models.ScopeCategoriesRead), categoryController.GetAllCategories)
+		categoryRoutes.GET("/tree", middlewares.RequireScope(models.ScopeCategoriesRead), categoryController.GetCategoryTree)
+		categoryRoutes.GET("/:id", middlewares.RequireScope(models.ScopeCategoriesRead), categoryController.GetCategoryByID)
+		categoryRoutes.PUT("/:id", middlewares.Authorize(models.RoleEditor, models.ScopeCategoriesWrite), categoryController.UpdateCategory)
+		categoryRoutes.DELETE("/:id", middlewares.Authorize(models.RoleEditor, models.ScopeCategoriesWrite), categoryController.DeleteCategory)
+		categoryRoutes.POST("/:id/restore", middlewares.Authorize(models.RoleEditor, models.ScopeCategoriesWrite), categoryController.RestoreCategory)
 	}
 }
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CustomerRoutes(router *gin.Engine, customerController controllers.CustomerController) {
+	customerRoutes := router.Group("/customers", middlewares.RequireRole(models.RoleViewer))
+	{
+		customerRoutes.POST("", middlewares.RequireRole(models.RoleEditor), customerController.CreateCustomer)
+		customerRoutes.GET("", customerController.GetAllCustomersWithPagination)
+		customerRoutes.GET("/:id", customerController.GetCustomerByID)
+		customerRoutes.PUT("/:id", middlewares.RequireRole(models.RoleEditor), customerController.UpdateCustomer)
+		customerRoutes.DELETE("/:id", middlewares.RequireRole(models.RoleEditor), customerController.DeleteCustomer)
+		customerRoutes.GET("/:id/orders", customerController.GetCustomerOrders)
+	}
+}
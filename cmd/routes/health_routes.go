@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func HealthRoutes(router *gin.Engine, healthController controllers.HealthController) {
+	router.GET("/healthz", healthController.Liveness)
+	router.GET("/readyz", healthController.Readiness)
+}
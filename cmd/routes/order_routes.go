@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func OrderRoutes(router *gin.Engine, orderController controllers.OrderController) {
+	orderRoutes := router.Group("/orders", middlewares.RequireRole(models.RoleViewer))
+	{
+		orderRoutes.POST("", middlewares.RequireRole(models.RoleEditor), orderController.CreateOrder)
+		orderRoutes.GET("", orderController.GetAllOrdersWithPagination)
+		orderRoutes.GET("/:id", orderController.GetOrderByID)
+	}
+}
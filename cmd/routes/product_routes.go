@@ -2,6 +2,8 @@ package routes
 
 import (
 	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 
 	"github.com/gin-gonic/gin"
 )
@@ -9,10 +11,11 @@ import (
 func ProductRoutes(router *gin.Engine, productController controllers.ProductController) {
 	productRoutes := router.Group("/products")
 	{
-		productRoutes.POST("", productController.CreateProduct)
-		productRoutes.GET("", productController.GetAllProductsWithPagination)
-		productRoutes.GET("/:id", productController.GetProductByID)
-		productRoutes.PUT("/:id", productController.UpdateProduct)
-		productRoutes.DELETE("/:id", productController.DeleteProduct)
+		productRoutes.POST("", middlewares.Authorize(models.RoleEditor, models.ScopeProductsWrite), productController.CreateProduct)
+		productRoutes.GET("", middlewares.RequireRoleWhenQuery("include_deleted", "true", models.RoleAdmin), middlewares.RequireScope(models.ScopeProductsRead), productController.GetAllProductsWithPagination)
+		productRoutes.GET("/:id", middlewares.RequireScope(models.ScopeProductsRead), productController.GetProductByID)
+		productRoutes.PUT("/:id", middlewares.Authorize(models.RoleEditor, models.ScopeProductsWrite), productController.UpdateProduct)
+		productRoutes.DELETE("/:id", middlewares.Authorize(models.RoleEditor, models.ScopeProductsWrite), productController.DeleteProduct)
+		productRoutes.POST("/:id/restore", middlewares.Authorize(models.RoleEditor, models.ScopeProductsWrite), productController.RestoreProduct)
 	}
 }
@@ -2,13 +2,18 @@ package routes
 
 import (
 	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 
 	"github.com/gin-gonic/gin"
 )
 
 func ReportRoutes(router *gin.Engine, reportController controllers.ReportController) {
-	categoryRoutes := router.Group("/reports")
+	categoryRoutes := router.Group("/reports", middlewares.Authorize(models.RoleViewer, models.ScopeReportsRead))
 	{
 		categoryRoutes.GET("/products", reportController.GetProductReport)
+		categoryRoutes.GET("/top-customers", reportController.GetTopCustomersReport)
+		categoryRoutes.GET("/product-sales", reportController.GetProductSalesReport)
+		categoryRoutes.GET("/sales-timeseries", reportController.GetSalesTimeseriesReport)
 	}
 }
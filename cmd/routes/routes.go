@@ -1,28 +1,81 @@
 package routes
 
 import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/configs"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/ratelimit"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 )
 
-func SetupRouter(r *gin.Engine) {
+// SetupRouter registers the routes of the API. The work that outlives its requests, such as the
+// report generations, is tracked by background
+func SetupRouter(r *gin.Engine, config configs.Config, redisClient *redis.Client, logger *slog.Logger, background *sync.WaitGroup) {
+	// Authenticate every request, route groups enforce roles on top of it
+	userRepo := repositories.NewUserRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, []byte(config.Auth.JWTSecret), config.Auth.AccessTTL, config.Auth.RefreshTTL)
+	authController := controllers.NewAuthController(authService)
+
+	// Machine clients authenticate with an X-API-Key instead
+	apiKeyRepo := repositories.NewAPIKeyRepository(configs.DB)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo)
+	apiKeyController := controllers.NewAPIKeyController(apiKeyService)
+
+	// Reports are cached in Redis and invalidated by the writes to the data they are built from
+	reportCache := cache.NewRedisCache(redisClient)
+	cacheController := controllers.NewCacheController(services.NewCacheService(reportCache))
+
+	// Limit each IP address, then each client once it is identified, falling back to per-instance
+	// limits without Redis
+	limiter := ratelimit.NewRedisLimiter(redisClient, ratelimit.NewMemoryLimiter(), logger)
+
+	// The IP limit runs first, so the requests with invalid credentials are limited too
+	r.Use(
+		middlewares.RateLimitIP(limiter, config.RateLimits),
+		middlewares.Authenticate(authService),
+		middlewares.AuthenticateAPIKey(apiKeyService),
+		middlewares.RateLimit(limiter, config.RateLimits),
+	)
+	AuthRoutes(r, authController)
+	AdminRoutes(r, apiKeyController, cacheController)
+
+	// Create the initial admin account
+	if err := authService.EnsureAdminUser(context.Background(), config.Auth.AdminEmail, config.Auth.AdminPassword); err != nil {
+		logger.Error("could not create admin user", "error", err)
+	}
+
 	// Initialize Repository, Service, and Controller
 	productRepo := repositories.NewProductRepository(configs.DB)
-	productService := services.NewProductService(productRepo)
+	productService := services.NewProductService(productRepo, reportCache)
 	productController := controllers.NewProductController(productService)
 	ProductRoutes(r, productController)
 
 	categoryRepo := repositories.NewCategoryRepository(configs.DB)
-	categoryService := services.NewCategoryService(categoryRepo)
+	categoryService := services.NewCategoryService(categoryRepo, reportCache)
 	categoryController := controllers.NewCategoryController(categoryService)
 	CategoryRoutes(r, categoryController)
 
 	reportRepo := repositories.NewReportRepository(configs.DB)
-	reportService := services.NewReportService(reportRepo)
+	reportService := services.NewReportService(reportRepo, reportCache, config.Cache, background)
 	reportController := controllers.NewReportController(reportService)
 	ReportRoutes(r, reportController)
+
+	orderRepo := repositories.NewOrderRepository(configs.DB)
+	orderService := services.NewOrderService(orderRepo, reportCache)
+	orderController := controllers.NewOrderController(orderService)
+	OrderRoutes(r, orderController)
+
+	customerRepo := repositories.NewCustomerRepository(configs.DB)
+	customerService := services.NewCustomerService(customerRepo, reportCache)
+	customerController := controllers.NewCustomerController(customerService)
+	CustomerRoutes(r, customerController)
 }
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+)
+
+const apiKeyPrefix = "ek_"
+
+type apiKeyService struct {
+	Repo repositories.APIKeyRepository
+}
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, request models.CreateAPIKeyRequest) (models.CreatedAPIKey, error)
+	GetAllAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id uint) error
+	Authenticate(ctx context.Context, key string) (models.APIKey, error)
+}
+
+func NewAPIKeyService(repo repositories.APIKeyRepository) *apiKeyService {
+	return &apiKeyService{Repo: repo}
+}
+
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, request models.CreateAPIKeyRequest) (models.CreatedAPIKey, error) {
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		return models.CreatedAPIKey{}, models.ErrExpiryInPast
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return models.CreatedAPIKey{}, err
+	}
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+
+	apiKey := models.APIKey{
+		Name:      request.Name,
+		Prefix:    key[:len(apiKeyPrefix)+8],
+		KeyHash:   hashAPIKey(key),
+		Scopes:    request.Scopes,
+		ExpiresAt: request.ExpiresAt,
+	}
+	if err := s.Repo.CreateAPIKey(ctx, &apiKey); err != nil {
+		return models.CreatedAPIKey{}, err
+	}
+	return models.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+func (s *apiKeyService) GetAllAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	return s.Repo.GetAllAPIKeys(ctx)
+}
+
+func (s *apiKeyService) DeleteAPIKey(ctx context.Context, id uint) error {
+	return s.Repo.DeleteAPIKey(ctx, id)
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (models.APIKey, error) {
+	apiKey, err := s.Repo.GetAPIKeyByHash(ctx, hashAPIKey(key))
+	if err != nil || apiKey.IsExpired(time.Now()) {
+		return models.APIKey{}, models.ErrInvalidAPIKey
+	}
+	return apiKey, nil
+}
+
+// Keys carry 256 bits of randomness, a plain SHA-256 is enough to make the stored hash useless
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
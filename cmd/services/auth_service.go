@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+)
+
+// Compared against when the e-mail is unknown, so a login takes as long whether or not the user exists
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+type authService struct {
+	Repo       repositories.UserRepository
+	Secret     []byte
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+type AuthService interface {
+	Login(ctx context.Context, email string, password string) (models.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (models.TokenPair, error)
+	ParseAccessToken(accessToken string) (models.AuthUser, error)
+	EnsureAdminUser(ctx context.Context, email string, password string) error
+}
+
+type tokenClaims struct {
+	Role      string `json:"role"`
+	TokenType string `json:"token_type"`
+	jwt.RegisteredClaims
+}
+
+func NewAuthService(repo repositories.UserRepository, secret []byte, accessTTL time.Duration, refreshTTL time.Duration) *authService {
+	return &authService{Repo: repo, Secret: secret, AccessTTL: accessTTL, RefreshTTL: refreshTTL}
+}
+
+func (s *authService) Login(ctx context.Context, email string, password string) (models.TokenPair, error) {
+	user, err := s.Repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return models.TokenPair{}, models.ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return models.TokenPair{}, models.ErrInvalidCredentials
+	}
+	return s.issueTokens(user)
+}
+
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (models.TokenPair, error) {
+	claims, err := s.parseToken(refreshToken, refreshTokenType)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	// Reload the user, so a changed role or a removed user takes effect on the next refresh
+	userID, _ := strconv.Atoi(claims.Subject)
+	user, err := s.Repo.GetUserByID(ctx, uint(userID))
+	if err != nil {
+		return models.TokenPair{}, models.ErrInvalidToken
+	}
+	return s.issueTokens(user)
+}
+
+func (s *authService) ParseAccessToken(accessToken string) (models.AuthUser, error) {
+	claims, err := s.parseToken(accessToken, accessTokenType)
+	if err != nil {
+		return models.AuthUser{}, err
+	}
+	userID, _ := strconv.Atoi(claims.Subject)
+	return models.AuthUser{ID: uint(userID), Role: claims.Role}, nil
+}
+
+func (s *authService) EnsureAdminUser(ctx context.Context, email string, password string) error {
+	if email == "" || password == "" {
+		return nil
+	}
+	if _, err := s.Repo.GetUserByEmail(ctx, email); err == nil {
+		return nil
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	return s.Repo.CreateUser(ctx, &models.User{Email: email, PasswordHash: string(passwordHash), Role: models.RoleAdmin})
+}
+
+func (s *authService) issueTokens(user models.User) (models.TokenPair, error) {
+	accessToken, err := s.signToken(user, accessTokenType, s.AccessTTL)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	refreshToken, err := s.signToken(user, refreshTokenType, s.RefreshTTL)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	return models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.AccessTTL.Seconds()),
+	}, nil
+}
+
+func (s *authService) signToken(user models.User, tokenType string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := tokenClaims{
+		Role:      user.Role,
+		TokenType: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(int(user.ID)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.Secret)
+}
+
+func (s *authService) parseToken(token string, tokenType string) (*tokenClaims, error) {
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return s.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.TokenType != tokenType {
+		return nil, models.ErrInvalidToken
+	}
+	return claims, nil
+}
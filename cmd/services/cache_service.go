@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+)
+
+type cacheService struct {
+	Cache cache.Cache
+}
+
+type CacheService interface {
+	PurgeReports(ctx context.Context, tag string) error
+}
+
+func NewCacheService(reportCache cache.Cache) *cacheService {
+	return &cacheService{Cache: reportCache}
+}
+
+// PurgeReports drops the cached reports built from the data of tag, or every cached report when
+// tag is empty
+func (s *cacheService) PurgeReports(ctx context.Context, tag string) error {
+	switch tag {
+	case "":
+		tag = reportsCacheTag
+	case reportsCacheTag, productsCacheTag, categoriesCacheTag, ordersCacheTag, customersCacheTag:
+	default:
+		return fmt.Errorf("%w: tag must be one of %s, %s, %s, %s or %s", models.ErrUnknownCacheTag,
+			reportsCacheTag, productsCacheTag, categoriesCacheTag, ordersCacheTag, customersCacheTag)
+	}
+	return s.Cache.Invalidate(ctx, tag)
+}
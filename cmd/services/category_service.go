@@ -1,42 +1,149 @@
 package services
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
 )
 
 type categoryService struct {
-	Repo repositories.CategoryRepository
+	Repo  repositories.CategoryRepository
+	Cache cache.Cache
 }
 
 type CategoryService interface {
-	CreateCategory(category *models.Category) error
-	GetAllCategories() ([]models.Category, error)
-	GetCategoryByID(id uint) (models.Category, error)
-	UpdateCategory(category *models.Category) error
-	DeleteCategory(id uint) error
+	CreateCategory(ctx context.Context, category *models.Category) error
+	GetAllCategories(ctx context.Context) ([]models.Category, error)
+	GetAllCategoriesIncludingDeleted(ctx context.Context) ([]models.Category, error)
+	GetCategoryByID(ctx context.Context, id uint) (models.Category, error)
+	GetCategoryTree(ctx context.Context) ([]models.Category, error)
+	UpdateCategory(ctx context.Context, category *models.Category) error
+	DeleteCategory(ctx context.Context, id uint) error
+	ReassignProductsAndDeleteCategory(ctx context.Context, id uint, reassignTo uint) error
+	DeactivateProductsAndDeleteCategory(ctx context.Context, id uint) error
+	RestoreCategory(ctx context.Context, id uint) (models.Category, error)
+}
+
+func NewCategoryService(repo repositories.CategoryRepository, reportCache cache.Cache) *categoryService {
+	return &categoryService{Repo: repo, Cache: reportCache}
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, category *models.Category) error {
+	if err := s.validateParent(ctx, category); err != nil {
+		return err
+	}
+	return s.Repo.CreateCategory(ctx, category)
 }
 
-func NewCategoryService(repo repositories.CategoryRepository) *categoryService {
-	return &categoryService{Repo: repo}
+func (s *categoryService) GetAllCategories(ctx context.Context) ([]models.Category, error) {
+	return s.Repo.GetAllCategories(ctx)
 }
 
-func (s *categoryService) CreateCategory(category *models.Category) error {
-	return s.Repo.CreateCategory(category)
+func (s *categoryService) GetAllCategoriesIncludingDeleted(ctx context.Context) ([]models.Category, error) {
+	return s.Repo.GetAllCategoriesIncludingDeleted(ctx)
 }
 
-func (s *categoryService) GetAllCategories() ([]models.Category, error) {
-	return s.Repo.GetAllCategories()
+func (s *categoryService) GetCategoryByID(ctx context.Context, id uint) (models.Category, error) {
+	return s.Repo.GetCategoryByID(ctx, id)
+}
+
+func (s *categoryService) GetCategoryTree(ctx context.Context) ([]models.Category, error) {
+	categories, err := s.Repo.GetAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := []models.Category{}
+	childrenByParent := map[uint][]models.Category{}
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+		} else {
+			childrenByParent[*category.ParentID] = append(childrenByParent[*category.ParentID], category)
+		}
+	}
+
+	var attachChildren func(nodes []models.Category) []models.Category
+	attachChildren = func(nodes []models.Category) []models.Category {
+		for i := range nodes {
+			nodes[i].Children = attachChildren(childrenByParent[nodes[i].ID])
+		}
+		return nodes
+	}
+	return attachChildren(roots), nil
 }
 
-func (s *categoryService) GetCategoryByID(id uint) (models.Category, error) {
-	return s.Repo.GetCategoryByID(id)
+func (s *categoryService) UpdateCategory(ctx context.Context, category *models.Category) error {
+	if err := s.validateParent(ctx, category); err != nil {
+		return err
+	}
+	if err := s.Repo.UpdateCategory(ctx, category); err != nil {
+		return err
+	}
+	invalidateReports(ctx, s.Cache, categoriesCacheTag)
+	return nil
 }
 
-func (s *categoryService) UpdateCategory(category *models.Category) error {
-	return s.Repo.UpdateCategory(category)
+func (s *categoryService) DeleteCategory(ctx context.Context, id uint) error {
+	if err := s.Repo.DeleteCategory(ctx, id); err != nil {
+		return err
+	}
+	invalidateReports(ctx, s.Cache, categoriesCacheTag)
+	return nil
 }
 
-func (s *categoryService) DeleteCategory(id uint) error {
-	return s.Repo.DeleteCategory(id)
+func (s *categoryService) ReassignProductsAndDeleteCategory(ctx context.Context, id uint, reassignTo uint) error {
+	if err := s.Repo.ReassignProductsAndDeleteCategory(ctx, id, reassignTo); err != nil {
+		return err
+	}
+	invalidateReports(ctx, s.Cache, categoriesCacheTag, productsCacheTag)
+	return nil
+}
+
+func (s *categoryService) DeactivateProductsAndDeleteCategory(ctx context.Context, id uint) error {
+	if err := s.Repo.DeactivateProductsAndDeleteCategory(ctx, id); err != nil {
+		return err
+	}
+	invalidateReports(ctx, s.Cache, categoriesCacheTag, productsCacheTag)
+	return nil
+}
+
+func (s *categoryService) RestoreCategory(ctx context.Context, id uint) (models.Category, error) {
+	restored, err := s.Repo.RestoreCategory(ctx, id)
+	if err != nil {
+		return restored, err
+	}
+	invalidateReports(ctx, s.Cache, categoriesCacheTag)
+	return restored, nil
+}
+
+// validateParent checks the parent of a category exists and that walking up from it never reaches the category itself
+func (s *categoryService) validateParent(ctx context.Context, category *models.Category) error {
+	if category.ParentID == nil {
+		return nil
+	}
+
+	categories, err := s.Repo.GetAllCategories(ctx)
+	if err != nil {
+		return err
+	}
+	parents := map[uint]*uint{}
+	for _, c := range categories {
+		parents[c.ID] = c.ParentID
+	}
+
+	visited := map[uint]bool{}
+	for ancestorID := category.ParentID; ancestorID != nil; ancestorID = parents[*ancestorID] {
+		if _, ok := parents[*ancestorID]; !ok {
+			return fmt.Errorf("%w: %d", models.ErrCategoryNotFound, *ancestorID)
+		}
+		if *ancestorID == category.ID || visited[*ancestorID] {
+			return models.ErrCategoryCycle
+		}
+		visited[*ancestorID] = true
+	}
+	return nil
 }
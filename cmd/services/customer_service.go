@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+type customerService struct {
+	Repo  repositories.CustomerRepository
+	Cache cache.Cache
+}
+
+type CustomerService interface {
+	CreateCustomer(ctx context.Context, customer *models.Customer) error
+	GetAllCustomersWithPagination(ctx *gin.Context) (models.CustomersPageable, error)
+	GetCustomerByID(ctx context.Context, id uint) (models.Customer, error)
+	UpdateCustomer(ctx context.Context, customer *models.Customer) (models.Customer, error)
+	DeleteCustomer(ctx context.Context, id uint) error
+	IsEmailTaken(ctx context.Context, email string, excludeID uint) (bool, error)
+	GetCustomerOrdersWithPagination(ctx *gin.Context, id uint) (models.OrdersPageable, error)
+}
+
+func NewCustomerService(repo repositories.CustomerRepository, reportCache cache.Cache) *customerService {
+	return &customerService{Repo: repo, Cache: reportCache}
+}
+
+func (s *customerService) CreateCustomer(ctx context.Context, customer *models.Customer) error {
+	if err := s.checkEmailAvailable(ctx, customer); err != nil {
+		return err
+	}
+	if err := s.Repo.CreateCustomer(ctx, customer); err != nil {
+		return err
+	}
+	invalidateReports(ctx, s.Cache, customersCacheTag)
+	return nil
+}
+
+func (s *customerService) GetAllCustomersWithPagination(ctx *gin.Context) (models.CustomersPageable, error) {
+	return s.Repo.GetAllCustomersWithPagination(ctx)
+}
+
+func (s *customerService) GetCustomerByID(ctx context.Context, id uint) (models.Customer, error) {
+	return s.Repo.GetCustomerByID(ctx, id)
+}
+
+func (s *customerService) UpdateCustomer(ctx context.Context, customer *models.Customer) (models.Customer, error) {
+	if err := s.checkEmailAvailable(ctx, customer); err != nil {
+		return models.Customer{}, err
+	}
+	updated, err := s.Repo.UpdateCustomer(ctx, customer)
+	if err != nil {
+		return updated, err
+	}
+	invalidateReports(ctx, s.Cache, customersCacheTag)
+	return updated, nil
+}
+
+func (s *customerService) DeleteCustomer(ctx context.Context, id uint) error {
+	if err := s.Repo.DeleteCustomer(ctx, id); err != nil {
+		return err
+	}
+	invalidateReports(ctx, s.Cache, customersCacheTag)
+	return nil
+}
+
+func (s *customerService) IsEmailTaken(ctx context.Context, email string, excludeID uint) (bool, error) {
+	return s.Repo.IsEmailTaken(ctx, email, excludeID)
+}
+
+func (s *customerService) GetCustomerOrdersWithPagination(ctx *gin.Context, id uint) (models.OrdersPageable, error) {
+	return s.Repo.GetCustomerOrdersWithPagination(ctx, id)
+}
+
+// checkEmailAvailable returns models.ErrEmailTaken when another customer has the email. The unique
+// index on customers.email still guards against concurrent writes
+func (s *customerService) checkEmailAvailable(ctx context.Context, customer *models.Customer) error {
+	taken, err := s.Repo.IsEmailTaken(ctx, customer.Email, customer.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return models.ErrEmailTaken
+	}
+	return nil
+}
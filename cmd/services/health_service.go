@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+)
+
+// HealthCheck reports whether a dependency is reachable, it has to return once ctx is done
+type HealthCheck func(ctx context.Context) error
+
+type healthService struct {
+	Checks       map[string]HealthCheck
+	Timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+type HealthService interface {
+	Readiness(ctx context.Context) models.HealthReport
+	MarkShuttingDown()
+}
+
+func NewHealthService(checks map[string]HealthCheck, timeout time.Duration) *healthService {
+	return &healthService{Checks: checks, Timeout: timeout}
+}
+
+// Readiness runs every check concurrently, each bounded by the timeout, the service is ready
+// when all of them pass and it is not shutting down
+func (s *healthService) Readiness(ctx context.Context) models.HealthReport {
+	report := models.HealthReport{
+		Status:       models.HealthStatusUp,
+		ShuttingDown: s.shuttingDown.Load(),
+		Checks:       make(map[string]models.DependencyHealth, len(s.Checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range s.Checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+			result := s.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != models.HealthStatusUp {
+				report.Status = models.HealthStatusDown
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if report.ShuttingDown {
+		report.Status = models.HealthStatusDown
+	}
+	return report
+}
+
+// MarkShuttingDown fails readiness from now on, so the orchestrator stops routing traffic here
+func (s *healthService) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s *healthService) run(ctx context.Context, check HealthCheck) models.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := models.DependencyHealth{Status: models.HealthStatusUp, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = models.HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
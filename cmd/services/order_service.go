@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+type orderService struct {
+	Repo  repositories.OrderRepository
+	Cache cache.Cache
+}
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, order *models.Order) error
+	GetAllOrdersWithPagination(ctx *gin.Context) (models.OrdersPageable, error)
+	GetOrderByID(ctx context.Context, id uint) (models.Order, error)
+}
+
+func NewOrderService(repo repositories.OrderRepository, reportCache cache.Cache) *orderService {
+	return &orderService{Repo: repo, Cache: reportCache}
+}
+
+func (s *orderService) CreateOrder(ctx context.Context, order *models.Order) error {
+	if err := s.Repo.CreateOrder(ctx, order); err != nil {
+		return err
+	}
+	// Orders take their items out of the product stock
+	invalidateReports(ctx, s.Cache, ordersCacheTag, productsCacheTag)
+	return nil
+}
+
+func (s *orderService) GetAllOrdersWithPagination(ctx *gin.Context) (models.OrdersPageable, error) {
+	return s.Repo.GetAllOrdersWithPagination(ctx)
+}
+
+func (s *orderService) GetOrderByID(ctx context.Context, id uint) (models.Order, error) {
+	return s.Repo.GetOrderByID(ctx, id)
+}
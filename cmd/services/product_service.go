@@ -1,6 +1,9 @@
 package services
 
 import (
+	"context"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
 
@@ -8,42 +11,66 @@ import (
 )
 
 type productService struct {
-	Repo repositories.ProductRepository
+	Repo  repositories.ProductRepository
+	Cache cache.Cache
 }
 
 type ProductService interface {
-	CreateProduct(product *models.Product) error
-	GetAllProducts() ([]models.Product, error)
+	CreateProduct(ctx context.Context, product *models.Product) error
+	GetAllProducts(ctx context.Context) ([]models.Product, error)
 	GetAllProductsWithPagination(ctx *gin.Context) (models.ProductsPageable, error)
-	GetProductByID(id uint) (models.Product, error)
-	UpdateProduct(product *models.Product) (models.Product, error)
-	DeleteProduct(id uint) error
+	GetProductByID(ctx context.Context, id uint) (models.Product, error)
+	UpdateProduct(ctx context.Context, product *models.Product) (models.Product, error)
+	DeleteProduct(ctx context.Context, id uint) error
+	RestoreProduct(ctx context.Context, id uint) (models.Product, error)
 }
 
-func NewProductService(repo repositories.ProductRepository) *productService {
-	return &productService{Repo: repo}
+func NewProductService(repo repositories.ProductRepository, reportCache cache.Cache) *productService {
+	return &productService{Repo: repo, Cache: reportCache}
 }
 
-func (s *productService) CreateProduct(product *models.Product) error {
-	return s.Repo.CreateProduct(product)
+func (s *productService) CreateProduct(ctx context.Context, product *models.Product) error {
+	if err := s.Repo.CreateProduct(ctx, product); err != nil {
+		return err
+	}
+	invalidateReports(ctx, s.Cache, productsCacheTag)
+	return nil
 }
 
-func (s *productService) GetAllProducts() ([]models.Product, error) {
-	return s.Repo.GetAllProducts()
+func (s *productService) GetAllProducts(ctx context.Context) ([]models.Product, error) {
+	return s.Repo.GetAllProducts(ctx)
 }
 
 func (s *productService) GetAllProductsWithPagination(ctx *gin.Context) (models.ProductsPageable, error) {
 	return s.Repo.GetAllProductsWithPagination(ctx)
 }
 
-func (s *productService) GetProductByID(id uint) (models.Product, error) {
-	return s.Repo.GetProductByID(id)
+func (s *productService) GetProductByID(ctx context.Context, id uint) (models.Product, error) {
+	return s.Repo.GetProductByID(ctx, id)
+}
+
+func (s *productService) UpdateProduct(ctx context.Context, product *models.Product) (models.Product, error) {
+	updated, err := s.Repo.UpdateProduct(ctx, product)
+	if err != nil {
+		return updated, err
+	}
+	invalidateReports(ctx, s.Cache, productsCacheTag)
+	return updated, nil
 }
 
-func (s *productService) UpdateProduct(product *models.Product) (models.Product, error) {
-	return s.Repo.UpdateProduct(product)
+func (s *productService) DeleteProduct(ctx context.Context, id uint) error {
+	if err := s.Repo.DeleteProduct(ctx, id); err != nil {
+		return err
+	}
+	invalidateReports(ctx, s.Cache, productsCacheTag)
+	return nil
 }
 
-func (s *productService) DeleteProduct(id uint) error {
-	return s.Repo.DeleteProduct(id)
+func (s *productService) RestoreProduct(ctx context.Context, id uint) (models.Product, error) {
+	restored, err := s.Repo.RestoreProduct(ctx, id)
+	if err != nil {
+		return restored, err
+	}
+	invalidateReports(ctx, s.Cache, productsCacheTag)
+	return restored, nil
 }
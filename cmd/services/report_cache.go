@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/metrics"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache tags of the data the reports are built from, writes to that data invalidate the reports
+// tagged with it. Every report is tagged with reportsCacheTag
+const (
+	reportsCacheTag    = "reports"
+	productsCacheTag   = "products"
+	categoriesCacheTag = "categories"
+	ordersCacheTag     = "orders"
+	customersCacheTag  = "customers"
+)
+
+var reportTags = map[string][]string{
+	"products":         {reportsCacheTag, productsCacheTag, categoriesCacheTag},
+	"top_customers":    {reportsCacheTag, ordersCacheTag, customersCacheTag},
+	"product_sales":    {reportsCacheTag, ordersCacheTag, productsCacheTag, categoriesCacheTag},
+	"sales_timeseries": {reportsCacheTag, ordersCacheTag, productsCacheTag, categoriesCacheTag},
+}
+
+// How often a caller waiting on the generation of a report by another instance looks for it in the cache
+const reportLockPollInterval = 50 * time.Millisecond
+
+// cachedReport is what is stored in the cache, the generation time gives the Age of cached reports
+type cachedReport[T any] struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Report      T         `json:"report"`
+}
+
+// generatedReport is the result of a generation shared by concurrent callers. waited is set when the
+// report was generated by another instance instead
+type generatedReport[T any] struct {
+	report T
+	waited bool
+}
+
+// reportCacheKey derives the cache key of a report from a hash of its parsed query, so the requests
+// spelling the same query differently (page=01, is_optimized=true for strategy=goroutines...) share it
+func reportCacheKey(name string, query any) (string, error) {
+	canonical, err := json.Marshal(query)
+	if err != nil {
+		return "", err
+	}
+	return hashReportCacheKey(name, canonical), nil
+}
+
+func hashReportCacheKey(name string, canonical []byte) string {
+	hash := sha256.Sum256(canonical)
+	return "report:" + name + ":" + hex.EncodeToString(hash[:])
+}
+
+// invalidateReports drops the cached reports built from the data of the tags. The write that changed
+// the data has already succeeded, so a failure is only logged and the reports expire with their TTL.
+// The invalidation is not cancelled with the request, but joins its trace and logs
+func invalidateReports(ctx context.Context, reportCache cache.Cache, tags ...string) {
+	if err := reportCache.Invalidate(context.WithoutCancel(ctx), tags...); err != nil {
+		logging.FromContext(ctx).Warn("could not invalidate cached reports", "tags", tags, "error", err)
+	}
+}
+
+// readCachedReport returns the cached report of key. An unavailable cache must not fail the report,
+// so errors are logged and reported as a miss
+func readCachedReport[T any](ctx context.Context, reportCache cache.Cache, key string) (cachedReport[T], bool) {
+	var cached cachedReport[T]
+	value, err := reportCache.Get(ctx, key)
+	if err == nil {
+		err = json.Unmarshal(value, &cached)
+	}
+	if err != nil {
+		if !errors.Is(err, cache.ErrMiss) {
+			logging.FromContext(ctx).Warn("could not read cached report", "key", key, "error", err)
+		}
+		return cached, false
+	}
+	return cached, true
+}
+
+// detachContext returns a copy of the request context that outlives the request. Gin contexts are
+// reused once the handler returns, and a generation shared by several requests must not be
+// cancelled when the one that started it goes away
+func detachContext(ctx *gin.Context) *gin.Context {
+	detached := ctx.Copy()
+	detached.Request = ctx.Request.WithContext(context.WithoutCancel(ctx.Request.Context()))
+	return detached
+}
+
+// getOrGenerateReport returns the cached report, or generates and caches it on a cache miss.
+// Concurrent misses of a report are collapsed into a single generation, and an expired report is
+// served while it is regenerated in the background as long as it is within the stale TTL.
+// name identifies the report in the metrics and key in the cache
+func getOrGenerateReport[T any](ctx *gin.Context, s *reportService, name, key string, generate func(ctx *gin.Context) (T, error)) (report T, status models.ReportCacheStatus, err error) {
+	end := tracing.StartRequestSpan(ctx, "ReportService.getOrGenerateReport", attribute.String("report.name", name))
+	defer func() { end(err) }()
+	span := trace.SpanFromContext(ctx.Request.Context())
+
+	logger := logging.FromContext(ctx)
+	cached, found := readCachedReport[T](ctx, s.Cache, key)
+	if found {
+		age := time.Since(cached.GeneratedAt)
+		status = models.ReportCacheStatus{Hit: true, Age: age, TTL: s.Config.ReportTTL - age}
+		if status.TTL > 0 {
+			logger.Debug("report cache hit", "key", key)
+			metrics.CacheHit(name)
+			span.SetAttributes(attribute.Bool("report.cache_hit", true))
+			return cached.Report, status, nil
+		}
+		if s.Config.ReportStaleTTL > 0 {
+			logger.Debug("report cache stale", "key", key)
+			metrics.CacheStale(name)
+			span.SetAttributes(attribute.Bool("report.cache_hit", true), attribute.Bool("report.cache_stale", true))
+			// The refresh is shared with the concurrent requests, its result is only cached
+			detached := detachContext(ctx)
+			s.generate(key, func() (any, error) {
+				refreshed, err := regenerateReport(detached, s, name, key, cached.GeneratedAt, generate)
+				if err != nil {
+					logging.FromContext(detached).Warn("could not refresh stale report", "key", key, "error", err)
+				}
+				return refreshed, err
+			})
+			return cached.Report, status, nil
+		}
+	}
+	logger.Debug("report cache miss", "key", key)
+	metrics.CacheMiss(name)
+	span.SetAttributes(attribute.Bool("report.cache_hit", false))
+
+	detached := detachContext(ctx)
+	results := s.generate(key, func() (any, error) {
+		return regenerateReport(detached, s, name, key, cached.GeneratedAt, generate)
+	})
+	select {
+	case <-ctx.Request.Context().Done():
+		return report, models.ReportCacheStatus{}, ctx.Request.Context().Err()
+	case result := <-results:
+		if result.Err != nil {
+			return report, models.ReportCacheStatus{}, result.Err
+		}
+		generated := result.Val.(generatedReport[T])
+		return generated.report, models.ReportCacheStatus{Collapsed: result.Shared || generated.waited}, nil
+	}
+}
+
+// generate runs fn once for all the concurrent callers of key, like group.DoChan. The generation is
+// tracked by s.background until it completes, whether its callers still wait for it or not
+func (s *reportService) generate(key string, fn func() (any, error)) <-chan singleflight.Result {
+	s.background.Add(1)
+	results := make(chan singleflight.Result, 1)
+	go func() {
+		defer s.background.Done()
+		results <- <-s.group.DoChan(key, fn)
+	}()
+	return results
+}
+
+// regenerateReport generates and caches a report while holding its lock, so a single instance
+// generates it at a time. While another instance holds the lock, the report it caches is waited for,
+// then it is generated anyway. Waiting and generating share a single deadline of the lock timeout,
+// so a request never takes longer than REPORT_CACHE_LOCK_TIMEOUT. previous is when the report being
+// replaced was generated, zero when there is none
+func regenerateReport[T any](ctx *gin.Context, s *reportService, name, key string, previous time.Time, generate func(ctx *gin.Context) (T, error)) (generatedReport[T], error) {
+	logger := logging.FromContext(ctx)
+	deadline := time.Now().Add(s.Config.ReportLockTimeout)
+	for {
+		release, acquired, err := s.Cache.Lock(ctx, key, s.Config.ReportLockTimeout)
+		if err != nil {
+			logger.Warn("could not lock report generation", "key", key, "error", err)
+			break
+		}
+		if acquired {
+			defer release()
+		}
+		// The report may also have been cached between the cache miss and the lock
+		if cached, found := readCachedReport[T](ctx, s.Cache, key); found && cached.GeneratedAt.After(previous) {
+			return generatedReport[T]{report: cached.Report, waited: true}, nil
+		}
+		if acquired {
+			break
+		}
+		if time.Now().After(deadline) {
+			logger.Warn("timed out waiting for report generation", "key", key)
+			break
+		}
+		time.Sleep(reportLockPollInterval)
+	}
+
+	// A write invalidating the report while it is generated may not be seen by its queries, so the
+	// report is only cached if none of its tags was invalidated in the meantime
+	versions, err := s.Cache.TagVersions(ctx, reportTags[name]...)
+	if err != nil {
+		logger.Warn("could not read report cache tag versions", "key", key, "error", err)
+	}
+
+	timeoutCtx, cancel := context.WithDeadline(ctx.Request.Context(), deadline)
+	defer cancel()
+	generateCtx := ctx.Copy()
+	generateCtx.Request = ctx.Request.WithContext(timeoutCtx)
+	report, err := generate(generateCtx)
+	if err != nil {
+		return generatedReport[T]{}, err
+	}
+
+	value, err := json.Marshal(cachedReport[T]{GeneratedAt: time.Now(), Report: report})
+	if err != nil {
+		return generatedReport[T]{}, err
+	}
+	if versions != nil {
+		stored, err := s.Cache.SetIfUnchanged(ctx, key, value, s.Config.ReportTTL+s.Config.ReportStaleTTL, versions)
+		if err != nil {
+			logger.Warn("could not cache report", "key", key, "error", err)
+		} else if !stored {
+			logger.Debug("report invalidated while generated, not cached", "key", key)
+		}
+	}
+	return generatedReport[T]{report: report}, nil
+}
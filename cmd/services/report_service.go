@@ -1,69 +1,110 @@
 package services
 
 import (
-	"encoding/json"
-	"fmt"
-	"strconv"
-	"time"
+	"sync"
 
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/configs"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/tracing"
 
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/singleflight"
 )
 
+// How many exports an instance streams at once. Each holds a database connection for as long as
+// its client takes to download it, so this stays well below the pool (DB_MAX_OPEN_CONNS, 25 by default)
+const maxConcurrentExports = 4
+
 type reportService struct {
-	Repo repositories.ReportRepository
+	Repo   repositories.ReportRepository
+	Cache  cache.Cache
+	Config configs.CacheConfig
+	// Collapses the concurrent generations of a report within the instance
+	group singleflight.Group
+	// Tracks the generations, which outlive the requests that started them
+	background *sync.WaitGroup
+	// Slots of the exports in progress
+	exports chan struct{}
 }
 
 type ReportService interface {
-	GenerateProductReport(ctx *gin.Context, isOptimized bool) (map[string]interface{}, error)
+	GenerateProductReport(ctx *gin.Context, query models.ReportQuery) (models.ProductReport, models.ReportCacheStatus, error)
+	ExportProductReport(ctx *gin.Context, query models.ReportQuery, write func(row models.ProductExportRow) error) error
+	GenerateTopCustomersReport(ctx *gin.Context, query models.TopCustomersQuery) ([]models.TopCustomer, models.ReportCacheStatus, error)
+	GenerateProductSalesReport(ctx *gin.Context, query models.ProductSalesQuery) (models.ProductSalesPageable, models.ReportCacheStatus, error)
+	GenerateSalesTimeseriesReport(ctx *gin.Context, query models.SalesTimeseriesQuery) (models.SalesTimeseriesReport, models.ReportCacheStatus, error)
 }
 
-func NewReportService(repo repositories.ReportRepository) *reportService {
-	return &reportService{Repo: repo}
+func NewReportService(repo repositories.ReportRepository, reportCache cache.Cache, config configs.CacheConfig, background *sync.WaitGroup) *reportService {
+	return &reportService{Repo: repo, Cache: reportCache, Config: config, background: background, exports: make(chan struct{}, maxConcurrentExports)}
 }
 
-func (s *reportService) GenerateProductReport(ctx *gin.Context, isOptimized bool) (map[string]interface{}, error) {
-	rdb := configs.ClientRedis()
-	// Try to fetch the cached report
-	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
-	key := fmt.Sprintf("product_report_%d_page_size%d", page, pageSize)
-	cachedReport, err := rdb.Get(ctx, key).Result()
-
-	if err == redis.Nil || cachedReport == "" { // Cache miss, regenerate report
-		fmt.Print("Cache miss, regenerate report isOptimized:", isOptimized, "\n")
-		var (
-			report map[string]interface{}
-			err    error
-		)
-		if isOptimized {
-			report, err = s.Repo.GenerateProductReportWithGoroutines(ctx)
-		} else {
-			report, err = s.Repo.GenerateProductReport(ctx)
+func (s *reportService) GenerateProductReport(ctx *gin.Context, query models.ReportQuery) (models.ProductReport, models.ReportCacheStatus, error) {
+	end := tracing.StartRequestSpan(ctx, "ReportService.GenerateProductReport", attribute.String("report.strategy", query.Strategy))
+	key, err := reportCacheKey("products", query)
+	if err != nil {
+		end(err)
+		return models.ProductReport{}, models.ReportCacheStatus{}, err
+	}
+	report, status, err := getOrGenerateReport(ctx, s, "products", key, func(ctx *gin.Context) (models.ProductReport, error) {
+		switch query.Strategy {
+		case models.ReportStrategyGoroutines:
+			return s.Repo.GenerateProductReportWithGoroutines(ctx.Request.Context(), query)
+		case models.ReportStrategySingleQuery:
+			return s.Repo.GenerateProductReportSingleQuery(ctx.Request.Context(), query)
 		}
+		return s.Repo.GenerateProductReport(ctx.Request.Context(), query)
+	})
+	end(err)
+	return report, status, err
+}
 
-		if err != nil {
-			return nil, err
-		}
-		// Cache the report for 5 minutes
-		reportJson, err := json.Marshal(report)
-		if err != nil {
-			return nil, err
-		}
-		rdb.Set(ctx, key, string(reportJson), 5*time.Second)
-		return report, err
-	} else {
-		fmt.Print("Cache hit\n")
-		var cachedReportMap map[string]interface{}
-		err = json.Unmarshal([]byte(cachedReport), &cachedReportMap)
-		if err != nil {
-			return nil, err
-		}
-		report := cachedReportMap // Use cached report
-		return report, err
+// ExportProductReport streams the products of the report to write. Exports are not cached, they are
+// read from the database as they are written. Past maxConcurrentExports, exports are rejected with
+// models.ErrTooManyExports rather than queued
+func (s *reportService) ExportProductReport(ctx *gin.Context, query models.ReportQuery, write func(row models.ProductExportRow) error) error {
+	end := tracing.StartRequestSpan(ctx, "ReportService.ExportProductReport")
+	select {
+	case s.exports <- struct{}{}:
+		defer func() { <-s.exports }()
+	default:
+		end(models.ErrTooManyExports)
+		return models.ErrTooManyExports
+	}
+	err := s.Repo.ExportProductReport(ctx.Request.Context(), query, write)
+	end(err)
+	return err
+}
+
+func (s *reportService) GenerateTopCustomersReport(ctx *gin.Context, query models.TopCustomersQuery) ([]models.TopCustomer, models.ReportCacheStatus, error) {
+	key, err := reportCacheKey("top_customers", query)
+	if err != nil {
+		return nil, models.ReportCacheStatus{}, err
+	}
+	return getOrGenerateReport(ctx, s, "top_customers", key, func(ctx *gin.Context) ([]models.TopCustomer, error) {
+		return s.Repo.GenerateTopCustomersReport(ctx.Request.Context(), query)
+	})
+}
+
+func (s *reportService) GenerateProductSalesReport(ctx *gin.Context, query models.ProductSalesQuery) (models.ProductSalesPageable, models.ReportCacheStatus, error) {
+	key, err := reportCacheKey("product_sales", query)
+	if err != nil {
+		return models.ProductSalesPageable{}, models.ReportCacheStatus{}, err
+	}
+	return getOrGenerateReport(ctx, s, "product_sales", key, func(ctx *gin.Context) (models.ProductSalesPageable, error) {
+		return s.Repo.GenerateProductSalesReport(ctx.Request.Context(), query)
+	})
+}
 
+func (s *reportService) GenerateSalesTimeseriesReport(ctx *gin.Context, query models.SalesTimeseriesQuery) (models.SalesTimeseriesReport, models.ReportCacheStatus, error) {
+	key, err := reportCacheKey("sales_timeseries", query)
+	if err != nil {
+		return models.SalesTimeseriesReport{}, models.ReportCacheStatus{}, err
 	}
+	return getOrGenerateReport(ctx, s, "sales_timeseries", key, func(ctx *gin.Context) (models.SalesTimeseriesReport, error) {
+		return s.Repo.GenerateSalesTimeseriesReport(ctx.Request.Context(), query)
+	})
 }
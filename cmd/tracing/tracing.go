@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/ndkode/elabram-backend-recruitment"
+
+// Setup installs the global tracer provider and the W3C trace context propagator. The exporter
+// is selected with OTEL_TRACES_EXPORTER: "otlp" (configured through the standard OTEL_EXPORTER_OTLP_*
+// variables), "stdout" for local runs, or "none" (default) to disable tracing. The returned
+// function flushes and stops the exporter
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch exporterName := os.Getenv("OTEL_TRACES_EXPORTER"); exporterName {
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q, expected otlp, stdout or none", exporterName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Sampling follows OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG, read by the SDK
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the service, from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Start starts a span as a child of the span in ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartRequestSpan starts a span as a child of the request span and makes it the current span of
+// the request until end is called, so spans started by the next layers nest under it. It must not
+// be used from goroutines sharing the gin context, those use Start with ctx.Request.Context()
+func StartRequestSpan(ctx *gin.Context, name string, attrs ...attribute.KeyValue) (end func(err error)) {
+	request := ctx.Request
+	spanCtx, span := Start(request.Context(), name, attrs...)
+	ctx.Request = request.WithContext(spanCtx)
+
+	return func(err error) {
+		End(span, err)
+		ctx.Request = request
+	}
+}
+
+// End records err, if any, on span and ends it
+func End(span trace.Span, err error) {
+	RecordError(span, err)
+	span.End()
+}
+
+// RecordError marks span as failed with err, a nil err is ignored
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
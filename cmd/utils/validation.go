@@ -32,6 +32,10 @@ func ValidateStruct(data interface{}) []string {
 				message = fmt.Sprintf("%s must be less than or equal to %s", err.Field(), err.Param())
 			case "lt":
 				message = fmt.Sprintf("%s must be less than %s", err.Field(), err.Param())
+			case "oneof":
+				message = fmt.Sprintf("%s must be one of %s", err.Field(), err.Param())
+			case "email":
+				message = fmt.Sprintf("%s must be a valid email address", err.Field())
 			default:
 				message = fmt.Sprintf("%s is invalid", err.Field())
 			}
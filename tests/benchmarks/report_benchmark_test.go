@@ -0,0 +1,230 @@
+package benchmarks_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/configs"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Size of the seeded dataset, every 20th product is soft deleted
+const (
+	seededRootCategories = 10
+	seededSubcategories  = 4
+	seededProducts       = 50000
+)
+
+var (
+	setupOnce sync.Once
+	benchDB   *gorm.DB
+	setupErr  error
+)
+
+// Strategies generating the product report, compared on every scenario
+var strategies = []struct {
+	name     string
+	generate func(repositories.ReportRepository, context.Context, models.ReportQuery) (models.ProductReport, error)
+}{
+	{models.ReportStrategySequential, repositories.ReportRepository.GenerateProductReport},
+	{models.ReportStrategyGoroutines, repositories.ReportRepository.GenerateProductReportWithGoroutines},
+	{models.ReportStrategySingleQuery, repositories.ReportRepository.GenerateProductReportSingleQuery},
+}
+
+// Report queries the strategies are compared on
+var scenarios = []struct {
+	name  string
+	query models.ReportQuery
+}{
+	{"all", newQuery(func(query *models.ReportQuery) {})},
+	{"price_range", newQuery(func(query *models.ReportQuery) {
+		minPrice, maxPrice := 100.0, 500.0
+		query.MinPrice, query.MaxPrice = &minPrice, &maxPrice
+		query.SortBy, query.SortOrder = "price", "desc"
+	})},
+	{"name_search", newQuery(func(query *models.ReportQuery) { query.Name = "Steel" })},
+	{"category_subtree", newQuery(func(query *models.ReportQuery) {
+		query.CategoryID = 1
+		query.IncludeDescendants = true
+		query.SortBy = "stock_quantity"
+	})},
+	{"deep_page", newQuery(func(query *models.ReportQuery) { query.Page = 400 })},
+	{"empty_page", newQuery(func(query *models.ReportQuery) { query.Page = 100000 })},
+}
+
+// newQuery returns the default report query, as parsed from a request without parameters, changed by modify
+func newQuery(modify func(query *models.ReportQuery)) models.ReportQuery {
+	query := models.ReportQuery{SortBy: "name", SortOrder: "asc", Page: 1, PageSize: 10}
+	modify(&query)
+	return query
+}
+
+// openBenchmarkDB connects to the benchmark database, created and seeded on first use. It is reached
+// with the DB_* variables of the service, defaulting to the MySQL of docker compose, and is named
+// after BENCH_DB_NAME (default elabram_bench) so the development data is left alone
+func openBenchmarkDB(tb testing.TB) *gorm.DB {
+	setupOnce.Do(func() {
+		benchDB, setupErr = setupBenchmarkDB()
+	})
+	if setupErr != nil {
+		tb.Skipf("benchmark database unavailable: %v", setupErr)
+	}
+	return benchDB
+}
+
+func setupBenchmarkDB() (*gorm.DB, error) {
+	config := configs.DatabaseConfig{
+		Host:     envOrDefault("DB_HOST", "127.0.0.1"),
+		Username: envOrDefault("DB_USERNAME", "root"),
+		Password: envOrDefault("DB_PASSWORD", "rootpassword"),
+	}
+	port, err := strconv.Atoi(envOrDefault("DB_PORT", "3306"))
+	if err != nil {
+		return nil, err
+	}
+	config.Port = port
+	name := envOrDefault("BENCH_DB_NAME", "elabram_bench")
+
+	server, err := gorm.Open(mysql.Open(config.DSN()), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return nil, err
+	}
+	if err := server.Exec("CREATE DATABASE IF NOT EXISTS `" + name + "`").Error; err != nil {
+		return nil, err
+	}
+	if sqlDB, err := server.DB(); err == nil {
+		sqlDB.Close()
+	}
+
+	config.Name = name
+	db, err := gorm.Open(mysql.Open(config.DSN()), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.AutoMigrate(&models.Category{}, &models.Product{}); err != nil {
+		return nil, err
+	}
+	return db, seed(db)
+}
+
+// seed fills the database with a deterministic dataset, unless it is already there
+func seed(db *gorm.DB) error {
+	var products int64
+	if err := db.Unscoped().Model(&models.Product{}).Count(&products).Error; err != nil {
+		return err
+	}
+	if products == seededProducts {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM products").Error; err != nil {
+			return err
+		}
+		// Subcategories first, they reference their parent
+		if err := tx.Exec("DELETE FROM categories ORDER BY parent_id IS NULL").Error; err != nil {
+			return err
+		}
+
+		// A tree of root categories with their subcategories, the roots get the first IDs
+		var categoryIDs []uint
+		roots := make([]models.Category, seededRootCategories)
+		for i := range roots {
+			roots[i] = models.Category{ID: uint(i + 1), Name: fmt.Sprintf("Category %d", i+1)}
+		}
+		if err := tx.Create(&roots).Error; err != nil {
+			return err
+		}
+		for _, root := range roots {
+			categoryIDs = append(categoryIDs, root.ID)
+			for j := 0; j < seededSubcategories; j++ {
+				parentID := root.ID
+				child := models.Category{Name: fmt.Sprintf("Category %d.%d", root.ID, j+1), ParentID: &parentID}
+				if err := tx.Create(&child).Error; err != nil {
+					return err
+				}
+				categoryIDs = append(categoryIDs, child.ID)
+			}
+		}
+
+		materials := []string{"Steel", "Wooden", "Plastic", "Glass", "Leather"}
+		items := []string{"Chair", "Table", "Lamp", "Shelf", "Desk", "Bottle"}
+		random := rand.New(rand.NewSource(1))
+		deletedAt := gorm.DeletedAt{Time: time.Now(), Valid: true}
+		products := make([]models.Product, 0, seededProducts)
+		for i := 0; i < seededProducts; i++ {
+			product := models.Product{
+				Name:          fmt.Sprintf("%s %s %05d", materials[random.Intn(len(materials))], items[random.Intn(len(items))], i),
+				Price:         float64(random.Intn(100000)+100) / 100,
+				CategoryID:    categoryIDs[random.Intn(len(categoryIDs))],
+				StockQuantity: random.Intn(500),
+				IsActive:      true,
+			}
+			if i%20 == 0 {
+				product.DeletedAt = deletedAt
+			}
+			products = append(products, product)
+		}
+		return tx.CreateInBatches(&products, 1000).Error
+	})
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// The strategies must return the same report for every scenario. The average price may differ in its
+// last digits, as the rows are not summed in the same order
+func TestProductReportStrategiesAgree(t *testing.T) {
+	repo := repositories.NewReportRepository(openBenchmarkDB(t))
+
+	for _, scenario := range scenarios {
+		var expectedAvgPrice float64
+		var expectedJSON []byte
+		for i, strategy := range strategies {
+			report, err := strategy.generate(repo, context.Background(), scenario.query)
+			assert.Nil(t, err, scenario.name+"/"+strategy.name)
+			avgPrice := report.AvgPrice
+			report.AvgPrice = 0
+			actualJSON, err := json.Marshal(report)
+			assert.Nil(t, err)
+			if i == 0 {
+				expectedAvgPrice, expectedJSON = avgPrice, actualJSON
+				continue
+			}
+			assert.InDelta(t, expectedAvgPrice, avgPrice, 1e-6, scenario.name+"/"+strategy.name)
+			assert.JSONEq(t, string(expectedJSON), string(actualJSON), scenario.name+"/"+strategy.name)
+		}
+	}
+}
+
+func BenchmarkProductReport(b *testing.B) {
+	repo := repositories.NewReportRepository(openBenchmarkDB(b))
+
+	for _, scenario := range scenarios {
+		for _, strategy := range strategies {
+			b.Run(scenario.name+"/"+strategy.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := strategy.generate(repo, context.Background(), scenario.query); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCacheGetSet(t *testing.T) {
+	reportCache := cache.NewMemoryCache()
+
+	_, err := reportCache.Get(context.Background(), "report")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+
+	assert.Nil(t, reportCache.Set(context.Background(), "report", []byte(`{"total":1}`), time.Minute))
+	value, err := reportCache.Get(context.Background(), "report")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"total":1}`, string(value))
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	reportCache := cache.NewMemoryCache()
+
+	assert.Nil(t, reportCache.Set(context.Background(), "report", []byte("value"), 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+
+	_, err := reportCache.Get(context.Background(), "report")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+}
+
+func TestMemoryCacheDelete(t *testing.T) {
+	reportCache := cache.NewMemoryCache()
+	assert.Nil(t, reportCache.Set(context.Background(), "first", []byte("value"), time.Minute))
+	assert.Nil(t, reportCache.Set(context.Background(), "second", []byte("value"), time.Minute))
+
+	assert.Nil(t, reportCache.Delete(context.Background(), "first", "second"))
+
+	_, err := reportCache.Get(context.Background(), "first")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+	_, err = reportCache.Get(context.Background(), "second")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+}
+
+func TestMemoryCacheInvalidate(t *testing.T) {
+	reportCache := cache.NewMemoryCache()
+	assert.Nil(t, reportCache.Set(context.Background(), "products", []byte("value"), time.Minute, "reports", "products"))
+	assert.Nil(t, reportCache.Set(context.Background(), "customers", []byte("value"), time.Minute, "reports", "customers"))
+
+	assert.Nil(t, reportCache.Invalidate(context.Background(), "products"))
+
+	_, err := reportCache.Get(context.Background(), "products")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+	_, err = reportCache.Get(context.Background(), "customers")
+	assert.Nil(t, err)
+
+	assert.Nil(t, reportCache.Invalidate(context.Background(), "reports"))
+	_, err = reportCache.Get(context.Background(), "customers")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+}
+
+func TestMemoryCacheSetIfUnchanged(t *testing.T) {
+	reportCache := cache.NewMemoryCache()
+
+	versions, err := reportCache.TagVersions(context.Background(), "reports", "products")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"reports": 0, "products": 0}, versions)
+
+	stored, err := reportCache.SetIfUnchanged(context.Background(), "products", []byte("value"), time.Minute, versions)
+	assert.Nil(t, err)
+	assert.True(t, stored)
+
+	// The value is tagged, and a value built before the invalidation is left out
+	assert.Nil(t, reportCache.Invalidate(context.Background(), "products"))
+	_, err = reportCache.Get(context.Background(), "products")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+
+	stored, err = reportCache.SetIfUnchanged(context.Background(), "products", []byte("value"), time.Minute, versions)
+	assert.Nil(t, err)
+	assert.False(t, stored)
+	_, err = reportCache.Get(context.Background(), "products")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+
+	versions, err = reportCache.TagVersions(context.Background(), "reports", "products")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"reports": 0, "products": 1}, versions)
+}
+
+func TestMemoryCacheLock(t *testing.T) {
+	reportCache := cache.NewMemoryCache()
+
+	release, acquired, err := reportCache.Lock(context.Background(), "products", time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, acquired)
+
+	_, acquired, err = reportCache.Lock(context.Background(), "products", time.Minute)
+	assert.Nil(t, err)
+	assert.False(t, acquired)
+
+	release()
+	_, acquired, err = reportCache.Lock(context.Background(), "products", time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, acquired)
+}
+
+func TestMemoryCacheLockExpires(t *testing.T) {
+	reportCache := cache.NewMemoryCache()
+	release, _, err := reportCache.Lock(context.Background(), "products", 10*time.Millisecond)
+	assert.Nil(t, err)
+	time.Sleep(20 * time.Millisecond)
+
+	_, acquired, err := reportCache.Lock(context.Background(), "products", time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, acquired)
+
+	// The expired holder does not release the lock taken over since
+	release()
+	_, acquired, err = reportCache.Lock(context.Background(), "products", time.Minute)
+	assert.Nil(t, err)
+	assert.False(t, acquired)
+}
@@ -0,0 +1,217 @@
+package configs_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/configs"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+// setRequiredEnv sets every key without a default
+func setRequiredEnv(t *testing.T) {
+	t.Setenv("DB_HOST", "db")
+	t.Setenv("DB_USERNAME", "user")
+	t.Setenv("DB_NAME", "elabram")
+	t.Setenv("REDIS_HOST", "redis")
+	t.Setenv("JWT_SECRET", "3f9c2a7e5b1d4c8a9e6f0b2d7a4c1e8f")
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	setRequiredEnv(t)
+
+	config, err := configs.Load(nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ":8080", config.Server.Addr())
+	assert.Equal(t, "user:@tcp(db:3306)/elabram?charset=utf8mb4&parseTime=True&loc=Local", config.Database.DSN())
+	assert.Equal(t, "redis:6379", config.Redis.Addr())
+	assert.Equal(t, 25, config.Database.MaxOpenConns)
+	assert.Equal(t, 15*time.Minute, config.Auth.AccessTTL)
+	assert.Equal(t, ratelimit.Limit{Requests: 30, Window: time.Minute}, config.RateLimits["reports"])
+	assert.Equal(t, ratelimit.Limit{Requests: 600, Window: time.Minute}, config.RateLimits["ip"])
+	assert.Empty(t, config.Server.TrustedProxies)
+}
+
+func TestLoadListsEveryMissingKey(t *testing.T) {
+	for _, key := range []string{"DB_HOST", "DB_USERNAME", "DB_NAME", "REDIS_HOST", "JWT_SECRET", "CONFIG_FILE"} {
+		t.Setenv(key, "")
+	}
+
+	_, err := configs.Load(nil)
+
+	var configErr *configs.ConfigError
+	assert.True(t, errors.As(err, &configErr))
+	assert.Equal(t, []string{"DB_HOST", "DB_USERNAME", "DB_NAME", "REDIS_HOST", "JWT_SECRET"}, configErr.Missing)
+}
+
+func TestLoadInvalidValues(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("SERVER_PORT", "http")
+	t.Setenv("REPORT_CACHE_TTL", "5")
+	t.Setenv("RATE_LIMITS", "reports=many/1m")
+	t.Setenv("REPORT_CACHE_LOCK_TIMEOUT", "0s")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.1,proxy.local")
+
+	_, err := configs.Load(nil)
+
+	var configErr *configs.ConfigError
+	assert.True(t, errors.As(err, &configErr))
+	assert.Empty(t, configErr.Missing)
+	assert.Len(t, configErr.Invalid, 5)
+	assert.Contains(t, err.Error(), "TRUSTED_PROXIES")
+	assert.Contains(t, err.Error(), "SERVER_PORT")
+	assert.Contains(t, err.Error(), "REPORT_CACHE_TTL")
+	assert.Contains(t, err.Error(), "RATE_LIMITS")
+	assert.Contains(t, err.Error(), "REPORT_CACHE_LOCK_TIMEOUT")
+}
+
+func TestLoadRejectsZeroDurations(t *testing.T) {
+	tests := []struct {
+		env   string
+		value string
+		valid bool
+	}{
+		{env: "PURGE_INTERVAL", value: "0s"},
+		{env: "SOFT_DELETE_RETENTION", value: "0s"},
+		{env: "REPORT_CACHE_TTL", value: "0s"},
+		{env: "REPORT_CACHE_LOCK_TIMEOUT", value: "0s"},
+		{env: "HEALTH_CHECK_TIMEOUT", value: "0s"},
+		{env: "JWT_ACCESS_TTL", value: "0s"},
+		{env: "JWT_REFRESH_TTL", value: "0s"},
+		{env: "SHUTDOWN_TIMEOUT", value: "0s"},
+		{env: "PURGE_INTERVAL", value: "-1h"},
+		// Zero is meaningful for these
+		{env: "SHUTDOWN_DELAY", value: "0s", valid: true},
+		{env: "REPORT_CACHE_STALE_TTL", value: "0s", valid: true},
+		{env: "DB_CONN_MAX_LIFETIME", value: "0s", valid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env+"="+tt.value, func(t *testing.T) {
+			setRequiredEnv(t)
+			t.Setenv(tt.env, tt.value)
+
+			_, err := configs.Load(nil)
+
+			if tt.valid {
+				assert.Nil(t, err)
+				return
+			}
+			var configErr *configs.ConfigError
+			assert.True(t, errors.As(err, &configErr))
+			assert.Equal(t, []string{tt.env + " (must be a duration greater than zero, e.g. 30s)"}, configErr.Invalid)
+		})
+	}
+}
+
+func TestLoadRejectsLockTimeoutPastWriteTimeout(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("REPORT_CACHE_LOCK_TIMEOUT", "30s")
+	t.Setenv("SERVER_WRITE_TIMEOUT", "30s")
+
+	_, err := configs.Load(nil)
+
+	var configErr *configs.ConfigError
+	assert.True(t, errors.As(err, &configErr))
+	assert.Equal(t, []string{"REPORT_CACHE_LOCK_TIMEOUT (must be less than SERVER_WRITE_TIMEOUT)"}, configErr.Invalid)
+
+	// Without a write timeout the generation may take as long as it needs
+	t.Setenv("SERVER_WRITE_TIMEOUT", "0s")
+	_, err = configs.Load(nil)
+	assert.Nil(t, err)
+}
+
+func TestLoadRejectsPlaceholderSecrets(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("JWT_SECRET", "change-me")
+	t.Setenv("ADMIN_PASSWORD", "admin123")
+
+	_, err := configs.Load(nil)
+
+	var configErr *configs.ConfigError
+	assert.True(t, errors.As(err, &configErr))
+	assert.Equal(t, []string{"ADMIN_PASSWORD (must not be a placeholder value)", "JWT_SECRET (must not be a placeholder value)"}, configErr.Invalid)
+}
+
+func TestLoadYAMLFile(t *testing.T) {
+	setRequiredEnv(t)
+	path := writeFile(t, "config.yaml", `
+server:
+  port: 9090
+  trusted_proxies:
+    - 10.0.0.1
+    - 172.16.0.0/12
+database:
+  max_open_conns: 50
+redis:
+  db: 2
+  tls: true
+cache:
+  report_ttl: 10m
+rate_limits:
+  reports: 60/1m
+`)
+
+	config, err := configs.Load([]string{"-config", path})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 9090, config.Server.Port)
+	assert.Equal(t, []string{"10.0.0.1", "172.16.0.0/12"}, config.Server.TrustedProxies)
+	assert.Equal(t, 50, config.Database.MaxOpenConns)
+	assert.Equal(t, 2, config.Redis.DB)
+	assert.True(t, config.Redis.TLS)
+	assert.Equal(t, 10*time.Minute, config.Cache.ReportTTL)
+	assert.Equal(t, ratelimit.Limit{Requests: 60, Window: time.Minute}, config.RateLimits["reports"])
+	assert.Equal(t, ratelimit.Limit{Requests: 120, Window: time.Minute}, config.RateLimits["default"])
+}
+
+func TestLoadTOMLFile(t *testing.T) {
+	setRequiredEnv(t)
+	path := writeFile(t, "config.toml", `
+[database]
+host = "mysql"
+port = 3307
+
+[log]
+level = "debug"
+`)
+	t.Setenv("CONFIG_FILE", path)
+
+	config, err := configs.Load(nil)
+
+	assert.Nil(t, err)
+	// The environment takes precedence over the file
+	assert.Equal(t, "db", config.Database.Host)
+	assert.Equal(t, 3307, config.Database.Port)
+	assert.Equal(t, "debug", config.Log.Level)
+}
+
+func TestLoadUnknownFileKey(t *testing.T) {
+	setRequiredEnv(t)
+	path := writeFile(t, "config.yaml", "server:\n  prot: 9090\n")
+
+	_, err := configs.Load([]string{"-config", path})
+
+	assert.ErrorContains(t, err, "server.prot")
+}
+
+func TestLoadFlagsTakePrecedence(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("SERVER_PORT", "9090")
+
+	config, err := configs.Load([]string{"-server-port", "9191", "-redis-password", "hunter2"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 9191, config.Server.Port)
+	assert.Equal(t, "hunter2", config.Redis.Password)
+}
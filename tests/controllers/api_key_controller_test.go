@@ -0,0 +1,102 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostAPIKeyRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the APIKeyService
+	mockAPIKeyService := mocks.NewMockAPIKeyService(ctrl)
+
+	// Set up expectations
+	mockAPIKeyService.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return(models.CreatedAPIKey{
+		APIKey: models.APIKey{ID: 1, Name: "erp", Prefix: "ek_12345678", Scopes: []string{models.ScopeProductsRead}},
+		Key:    "ek_12345678secret",
+	}, nil)
+
+	// Set up the controller with the mocked service
+	apiKeyController := controllers.NewAPIKeyController(mockAPIKeyService)
+	r.POST("/admin/api-keys", apiKeyController.CreateAPIKey)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/admin/api-keys", strings.NewReader(`{"name":"erp","scopes":["products:read"]}`))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"key":"ek_12345678secret"`)
+	assert.NotContains(t, recorder.Body.String(), "key_hash")
+}
+
+func TestPostAPIKeyRouteUnknownScope(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the APIKeyService
+	mockAPIKeyService := mocks.NewMockAPIKeyService(ctrl)
+
+	// Set up the controller with the mocked service
+	apiKeyController := controllers.NewAPIKeyController(mockAPIKeyService)
+	r.POST("/admin/api-keys", apiKeyController.CreateAPIKey)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/admin/api-keys", strings.NewReader(`{"name":"erp","scopes":["orders:write"]}`))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "must be one of")
+}
+
+func TestDeleteAPIKeyRouteNotFound(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the APIKeyService
+	mockAPIKeyService := mocks.NewMockAPIKeyService(ctrl)
+
+	// Set up expectations
+	mockAPIKeyService.EXPECT().DeleteAPIKey(gomock.Any(), uint(9)).Return(models.ErrAPIKeyNotFound)
+
+	// Set up the controller with the mocked service
+	apiKeyController := controllers.NewAPIKeyController(mockAPIKeyService)
+	r.DELETE("/admin/api-keys/:id", apiKeyController.DeleteAPIKey)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodDelete, "/admin/api-keys/9", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
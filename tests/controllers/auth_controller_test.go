@@ -0,0 +1,132 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the AuthService
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+
+	// Set up expectations
+	mockAuthService.EXPECT().Login(gomock.Any(), "admin@mail.com", "admin123").Return(models.TokenPair{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		TokenType:    "Bearer",
+		ExpiresIn:    900,
+	}, nil)
+
+	// Set up the controller with the mocked service
+	authController := controllers.NewAuthController(mockAuthService)
+	r.POST("/auth/login", authController.Login)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{"email":"admin@mail.com","password":"admin123"}`))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"access_token":"access"`)
+}
+
+func TestLoginRouteInvalidCredentials(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the AuthService
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+
+	// Set up expectations
+	mockAuthService.EXPECT().Login(gomock.Any(), "admin@mail.com", "wrong").Return(models.TokenPair{}, models.ErrInvalidCredentials)
+
+	// Set up the controller with the mocked service
+	authController := controllers.NewAuthController(mockAuthService)
+	r.POST("/auth/login", authController.Login)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{"email":"admin@mail.com","password":"wrong"}`))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "error")
+}
+
+func TestLoginRouteBadRequest(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the AuthService
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+
+	// Set up the controller with the mocked service
+	authController := controllers.NewAuthController(mockAuthService)
+	r.POST("/auth/login", authController.Login)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{"email":"not-an-email"}`))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "errors")
+}
+
+func TestRefreshRouteInvalidToken(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the AuthService
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+
+	// Set up expectations
+	mockAuthService.EXPECT().Refresh(gomock.Any(), "expired").Return(models.TokenPair{}, models.ErrInvalidToken)
+
+	// Set up the controller with the mocked service
+	authController := controllers.NewAuthController(mockAuthService)
+	r.POST("/auth/refresh", authController.Refresh)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{"refresh_token":"expired"}`))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
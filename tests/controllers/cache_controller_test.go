@@ -0,0 +1,73 @@
+package controllers_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurgeCacheRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CacheService
+	mockCacheService := mocks.NewMockCacheService(ctrl)
+
+	// Set up expectations
+	mockCacheService.EXPECT().PurgeReports(gomock.Any(), "products").Return(nil)
+
+	// Set up the controller with the mocked service
+	cacheController := controllers.NewCacheController(mockCacheService)
+	r.POST("/admin/cache/purge", cacheController.PurgeCache)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/admin/cache/purge?tag=products", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "purged")
+}
+
+func TestPurgeCacheRouteUnknownTag(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CacheService
+	mockCacheService := mocks.NewMockCacheService(ctrl)
+
+	// Set up expectations
+	mockCacheService.EXPECT().PurgeReports(gomock.Any(), "widgets").Return(fmt.Errorf("%w: tag must be one of reports", models.ErrUnknownCacheTag))
+
+	// Set up the controller with the mocked service
+	cacheController := controllers.NewCacheController(mockCacheService)
+	r.POST("/admin/cache/purge", cacheController.PurgeCache)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/admin/cache/purge?tag=widgets", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "unknown cache tag")
+}
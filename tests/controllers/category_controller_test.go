@@ -3,6 +3,7 @@ package controllers_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -29,7 +30,7 @@ func TestPostCategoryRoute(t *testing.T) {
 	mockCategoryService := mocks.NewMockCategoryService(ctrl)
 
 	// Set up expectations
-	mockCategoryService.EXPECT().CreateCategory(gomock.Any()).Return(nil)
+	mockCategoryService.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Return(nil)
 
 	// Set up the controller with the mocked service
 	categoryController := controllers.NewCategoryController(mockCategoryService)
@@ -95,7 +96,7 @@ func TestGetAllCategoriesRoute(t *testing.T) {
 	mockCategoryService := mocks.NewMockCategoryService(ctrl)
 
 	// Set up expectations
-	mockCategoryService.EXPECT().GetAllCategories().Return([]models.Category{
+	mockCategoryService.EXPECT().GetAllCategories(gomock.Any()).Return([]models.Category{
 		{
 			ID:          1,
 			Name:        "category 1",
@@ -135,7 +136,7 @@ func TestGetCategoryByIdRoute(t *testing.T) {
 	mockCategoryService := mocks.NewMockCategoryService(ctrl)
 
 	// Set up expectations
-	mockCategoryService.EXPECT().GetCategoryByID(gomock.Any()).Return(models.Category{
+	mockCategoryService.EXPECT().GetCategoryByID(gomock.Any(), gomock.Any()).Return(models.Category{
 		ID:          1,
 		Name:        "category 1",
 		Description: "category product description 1",
@@ -168,7 +169,7 @@ func TestGetCategoryByIdRouteNotFound(t *testing.T) {
 	mockCategoryService := mocks.NewMockCategoryService(ctrl)
 
 	// Set up expectations
-	mockCategoryService.EXPECT().GetCategoryByID(gomock.Any()).Return(models.Category{}, errors.New("not found"))
+	mockCategoryService.EXPECT().GetCategoryByID(gomock.Any(), gomock.Any()).Return(models.Category{}, errors.New("not found"))
 
 	// Set up the controller with the mocked service
 	categoryController := controllers.NewCategoryController(mockCategoryService)
@@ -184,3 +185,316 @@ func TestGetCategoryByIdRouteNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "error")
 }
+
+func TestPutCategoryByIdRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up expectations
+	mockCategoryService.EXPECT().GetCategoryByID(gomock.Any(), uint(1)).Return(models.Category{
+		ID:          1,
+		Name:        "category 1",
+		Description: "category product description 1",
+	}, nil)
+	mockCategoryService.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.PUT("/categories/:id", categoryController.UpdateCategory)
+
+	// Create a new request
+	payload := models.Category{
+		Description: "changed category description 1",
+	}
+	payloadJson, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPut, "/categories/1", strings.NewReader(string(payloadJson)))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "category 1")
+	assert.Contains(t, recorder.Body.String(), "changed category description 1")
+}
+
+func TestDeleteCategoryByIdRouteInUse(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up expectations
+	mockCategoryService.EXPECT().GetCategoryByID(gomock.Any(), uint(1)).Return(models.Category{ID: 1}, nil)
+	mockCategoryService.EXPECT().DeleteCategory(gomock.Any(), uint(1)).Return(models.ErrCategoryInUse)
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.DELETE("/categories/:id", categoryController.DeleteCategory)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodDelete, "/categories/1", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "category still has products")
+}
+
+func TestDeleteCategoryByIdRouteReassign(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up expectations
+	mockCategoryService.EXPECT().GetCategoryByID(gomock.Any(), uint(1)).Return(models.Category{ID: 1}, nil)
+	mockCategoryService.EXPECT().ReassignProductsAndDeleteCategory(gomock.Any(), uint(1), uint(2)).Return(nil)
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.DELETE("/categories/:id", categoryController.DeleteCategory)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodDelete, "/categories/1?reassign_to=2", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "deleted")
+}
+
+func TestDeleteCategoryByIdRouteCascadeDeactivate(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up expectations
+	mockCategoryService.EXPECT().GetCategoryByID(gomock.Any(), uint(1)).Return(models.Category{ID: 1}, nil)
+	mockCategoryService.EXPECT().DeactivateProductsAndDeleteCategory(gomock.Any(), uint(1)).Return(nil)
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.DELETE("/categories/:id", categoryController.DeleteCategory)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodDelete, "/categories/1?cascade=deactivate", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "deleted")
+}
+
+func TestDeleteCategoryByIdRouteBadRequest(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.DELETE("/categories/:id", categoryController.DeleteCategory)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodDelete, "/categories/1?reassign_to=1", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "error")
+}
+
+func TestGetCategoryTreeRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up expectations
+	parentID := uint(1)
+	mockCategoryService.EXPECT().GetCategoryTree(gomock.Any()).Return([]models.Category{
+		{
+			ID:   1,
+			Name: "category 1",
+			Children: []models.Category{
+				{ID: 2, Name: "category 2", ParentID: &parentID},
+			},
+		},
+	}, nil)
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.GET("/categories/tree", categoryController.GetCategoryTree)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/categories/tree", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"children":[{"id":2,"name":"category 2","parent_id":1,"deleted_at":null}]`)
+}
+
+func TestPutCategoryByIdRouteCycle(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up expectations
+	mockCategoryService.EXPECT().GetCategoryByID(gomock.Any(), uint(1)).Return(models.Category{ID: 1, Name: "category 1"}, nil)
+	mockCategoryService.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Return(models.ErrCategoryCycle)
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.PUT("/categories/:id", categoryController.UpdateCategory)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPut, "/categories/1", strings.NewReader(`{"parent_id": 3}`))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "own ancestor")
+}
+
+func TestGetAllCategoriesRouteIncludeDeleted(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up expectations
+	mockCategoryService.EXPECT().GetAllCategoriesIncludingDeleted(gomock.Any()).Return([]models.Category{
+		{ID: 1, Name: "category 1"},
+		{ID: 2, Name: "deleted category 2"},
+	}, nil)
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.GET("/categories", categoryController.GetAllCategories)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/categories?include_deleted=true", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "deleted category 2")
+}
+
+func TestRestoreCategoryRouteNotFound(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up expectations
+	mockCategoryService.EXPECT().RestoreCategory(gomock.Any(), uint(2)).Return(models.Category{}, errors.New("record not found"))
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.POST("/categories/:id/restore", categoryController.RestoreCategory)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/categories/2/restore", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "error")
+}
+
+func TestRestoreCategoryRouteParentDeleted(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CategoryService
+	mockCategoryService := mocks.NewMockCategoryService(ctrl)
+
+	// Set up expectations
+	mockCategoryService.EXPECT().RestoreCategory(gomock.Any(), uint(2)).Return(models.Category{}, fmt.Errorf("%w: %d", models.ErrCategoryParentGone, 1))
+
+	// Set up the controller with the mocked service
+	categoryController := controllers.NewCategoryController(mockCategoryService)
+	r.POST("/categories/:id/restore", categoryController.RestoreCategory)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/categories/2/restore", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "parent category is deleted")
+}
@@ -0,0 +1,284 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostCustomerRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CustomerService
+	mockCustomerService := mocks.NewMockCustomerService(ctrl)
+
+	// Set up expectations
+	mockCustomerService.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Set up the controller with the mocked service
+	customerController := controllers.NewCustomerController(mockCustomerService)
+	r.POST("/customers", customerController.CreateCustomer)
+
+	// Create a new request
+	payload := models.Customer{
+		Name:  "Jane Doe",
+		Email: "jane@example.com",
+	}
+	payloadJson, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, "/customers", strings.NewReader(string(payloadJson)))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "jane@example.com")
+}
+
+func TestPostCustomerRouteDuplicateEmail(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CustomerService
+	mockCustomerService := mocks.NewMockCustomerService(ctrl)
+
+	// Set up expectations
+	mockCustomerService.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).Return(models.ErrEmailTaken)
+
+	// Set up the controller with the mocked service
+	customerController := controllers.NewCustomerController(mockCustomerService)
+	r.POST("/customers", customerController.CreateCustomer)
+
+	// Create a new request
+	payload := models.Customer{
+		Name:  "Jane Doe",
+		Email: "jane@example.com",
+	}
+	payloadJson, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, "/customers", strings.NewReader(string(payloadJson)))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "email is already registered")
+}
+
+func TestPutCustomerByIdRouteDuplicateEntry(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CustomerService
+	mockCustomerService := mocks.NewMockCustomerService(ctrl)
+
+	// Set up expectations, a concurrent write took the email after the service checked it
+	mockCustomerService.EXPECT().GetCustomerByID(gomock.Any(), uint(1)).Return(models.Customer{ID: 1, Name: "Jane Doe", Email: "jane@example.com"}, nil)
+	mockCustomerService.EXPECT().UpdateCustomer(gomock.Any(), gomock.Any()).Return(models.Customer{}, &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+	// Set up the controller with the mocked service
+	customerController := controllers.NewCustomerController(mockCustomerService)
+	r.PUT("/customers/:id", customerController.UpdateCustomer)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPut, "/customers/1", strings.NewReader(`{"email":"john@example.com"}`))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "email is already registered")
+}
+
+func TestPostCustomerRouteBadRequest(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CustomerService
+	mockCustomerService := mocks.NewMockCustomerService(ctrl)
+
+	// Set up the controller with the mocked service
+	customerController := controllers.NewCustomerController(mockCustomerService)
+	r.POST("/customers", customerController.CreateCustomer)
+
+	// Create a new request
+	payload := models.Customer{
+		Name:  "Jane Doe",
+		Email: "not-an-email",
+	}
+	payloadJson, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, "/customers", strings.NewReader(string(payloadJson)))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Email must be a valid email address")
+}
+
+func TestPutCustomerByIdRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CustomerService
+	mockCustomerService := mocks.NewMockCustomerService(ctrl)
+
+	// Set up expectations
+	mockCustomerService.EXPECT().GetCustomerByID(gomock.Any(), uint(1)).Return(models.Customer{
+		ID:    1,
+		Name:  "Jane Doe",
+		Email: "jane@example.com",
+	}, nil)
+	mockCustomerService.EXPECT().UpdateCustomer(gomock.Any(), gomock.Any()).Return(models.Customer{
+		ID:    1,
+		Name:  "Jane Doe",
+		Email: "jane.doe@example.com",
+	}, nil)
+
+	// Set up the controller with the mocked service
+	customerController := controllers.NewCustomerController(mockCustomerService)
+	r.PUT("/customers/:id", customerController.UpdateCustomer)
+
+	// Create a new request
+	payload := models.Customer{
+		Email: "jane.doe@example.com",
+	}
+	payloadJson, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPut, "/customers/1", strings.NewReader(string(payloadJson)))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "jane.doe@example.com")
+}
+
+func TestDeleteCustomerByIdRouteWithOrders(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CustomerService
+	mockCustomerService := mocks.NewMockCustomerService(ctrl)
+
+	// Set up expectations
+	mockCustomerService.EXPECT().DeleteCustomer(gomock.Any(), uint(1)).Return(models.ErrCustomerHasOrders)
+
+	// Set up the controller with the mocked service
+	customerController := controllers.NewCustomerController(mockCustomerService)
+	r.DELETE("/customers/:id", customerController.DeleteCustomer)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodDelete, "/customers/1", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "error")
+}
+
+func TestGetCustomerOrdersRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CustomerService
+	mockCustomerService := mocks.NewMockCustomerService(ctrl)
+
+	// Set up expectations
+	mockCustomerService.EXPECT().GetCustomerByID(gomock.Any(), uint(1)).Return(models.Customer{ID: 1}, nil)
+	mockCustomerService.EXPECT().GetCustomerOrdersWithPagination(gomock.Any(), uint(1)).Return(models.OrdersPageable{
+		Orders: []models.Order{
+			{ID: 7, CustomerID: 1, TotalPrice: 300},
+		},
+		Page:       1,
+		TotalItems: 1,
+		TotalPages: 1,
+	}, nil)
+
+	// Set up the controller with the mocked service
+	customerController := controllers.NewCustomerController(mockCustomerService)
+	r.GET("/customers/:id/orders", customerController.GetCustomerOrders)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/customers/1/orders?page=1&page_size=10", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"total_price":300`)
+}
+
+func TestGetCustomerOrdersRouteNotFound(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the CustomerService
+	mockCustomerService := mocks.NewMockCustomerService(ctrl)
+
+	// Set up expectations
+	mockCustomerService.EXPECT().GetCustomerByID(gomock.Any(), uint(2)).Return(models.Customer{}, errors.New("not found"))
+
+	// Set up the controller with the mocked service
+	customerController := controllers.NewCustomerController(mockCustomerService)
+	r.GET("/customers/:id/orders", customerController.GetCustomerOrders)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/customers/2/orders", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "error")
+}
@@ -0,0 +1,110 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLivenessRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the HealthService, liveness must not check dependencies
+	mockHealthService := mocks.NewMockHealthService(ctrl)
+
+	// Set up the controller with the mocked service
+	healthController := controllers.NewHealthController(mockHealthService)
+	r.GET("/healthz", healthController.Liveness)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/healthz", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"status":"up"`)
+}
+
+func TestReadinessRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the HealthService
+	mockHealthService := mocks.NewMockHealthService(ctrl)
+
+	// Set up expectations
+	mockHealthService.EXPECT().Readiness(gomock.Any()).Return(models.HealthReport{
+		Status: models.HealthStatusUp,
+		Checks: map[string]models.DependencyHealth{
+			"mysql": {Status: models.HealthStatusUp, LatencyMs: 1},
+			"redis": {Status: models.HealthStatusUp, LatencyMs: 1},
+		},
+	})
+
+	// Set up the controller with the mocked service
+	healthController := controllers.NewHealthController(mockHealthService)
+	r.GET("/readyz", healthController.Readiness)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"mysql":{"status":"up"`)
+}
+
+func TestReadinessRouteUnavailable(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the HealthService
+	mockHealthService := mocks.NewMockHealthService(ctrl)
+
+	// Set up expectations
+	mockHealthService.EXPECT().Readiness(gomock.Any()).Return(models.HealthReport{
+		Status: models.HealthStatusDown,
+		Checks: map[string]models.DependencyHealth{
+			"mysql": {Status: models.HealthStatusUp, LatencyMs: 1},
+			"redis": {Status: models.HealthStatusDown, LatencyMs: 2000, Error: "context deadline exceeded"},
+		},
+	})
+
+	// Set up the controller with the mocked service
+	healthController := controllers.NewHealthController(mockHealthService)
+	r.GET("/readyz", healthController.Readiness)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "context deadline exceeded")
+}
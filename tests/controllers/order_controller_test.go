@@ -0,0 +1,232 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostOrderRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the OrderService
+	mockOrderService := mocks.NewMockOrderService(ctrl)
+
+	// Set up expectations
+	mockOrderService.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *models.Order) error {
+		order.Items[0].UnitPrice = 100
+		order.TotalPrice = 200
+		return nil
+	})
+
+	// Set up the controller with the mocked service
+	orderController := controllers.NewOrderController(mockOrderService)
+	r.POST("/orders", orderController.CreateOrder)
+
+	// Create a new request
+	payload := models.Order{
+		CustomerID: 1,
+		Items: []models.OrderItem{
+			{ProductID: 1, Quantity: 2},
+		},
+	}
+	payloadJson, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, "/orders", strings.NewReader(string(payloadJson)))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"unit_price":100`)
+	assert.Contains(t, recorder.Body.String(), `"total_price":200`)
+}
+
+func TestPostOrderRouteBadRequest(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the OrderService
+	mockOrderService := mocks.NewMockOrderService(ctrl)
+
+	// Set up the controller with the mocked service
+	orderController := controllers.NewOrderController(mockOrderService)
+	r.POST("/orders", orderController.CreateOrder)
+
+	// Create a new request
+	payload := models.Order{
+		CustomerID: 1,
+		Items:      []models.OrderItem{},
+	}
+	payloadJson, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, "/orders", strings.NewReader(string(payloadJson)))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "errors")
+}
+
+func TestPostOrderRouteProductNotFound(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the OrderService
+	mockOrderService := mocks.NewMockOrderService(ctrl)
+
+	// Set up expectations
+	mockOrderService.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: %d", models.ErrProductNotFound, 99))
+
+	// Set up the controller with the mocked service
+	orderController := controllers.NewOrderController(mockOrderService)
+	r.POST("/orders", orderController.CreateOrder)
+
+	// Create a new request
+	payload := models.Order{
+		CustomerID: 1,
+		Items: []models.OrderItem{
+			{ProductID: 99, Quantity: 1},
+		},
+	}
+	payloadJson, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, "/orders", strings.NewReader(string(payloadJson)))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "product not found")
+}
+
+func TestPostOrderRouteInsufficientStock(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the OrderService
+	mockOrderService := mocks.NewMockOrderService(ctrl)
+
+	// Set up expectations
+	mockOrderService.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(&models.InsufficientStockError{
+		Shortages: []models.StockShortage{
+			{ProductID: 1, Requested: 5, Available: 2},
+		},
+	})
+
+	// Set up the controller with the mocked service
+	orderController := controllers.NewOrderController(mockOrderService)
+	r.POST("/orders", orderController.CreateOrder)
+
+	// Create a new request
+	payload := models.Order{
+		CustomerID: 1,
+		Items: []models.OrderItem{
+			{ProductID: 1, Quantity: 5},
+		},
+	}
+	payloadJson, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, "/orders", strings.NewReader(string(payloadJson)))
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"shortages":[{"product_id":1,"requested":5,"available":2}]`)
+}
+
+func TestGetOrdersRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the OrderService
+	mockOrderService := mocks.NewMockOrderService(ctrl)
+
+	// Set up expectations
+	mockOrderService.EXPECT().GetAllOrdersWithPagination(gomock.Any()).Return(models.OrdersPageable{
+		Orders: []models.Order{
+			{ID: 1, CustomerID: 1, TotalPrice: 200},
+		},
+		Page:       1,
+		TotalItems: 1,
+		TotalPages: 1,
+	}, nil)
+
+	// Set up the controller with the mocked service
+	orderController := controllers.NewOrderController(mockOrderService)
+	r.GET("/orders", orderController.GetAllOrdersWithPagination)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/orders", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"total_items":1`)
+}
+
+func TestGetOrderByIdRouteNotFound(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the OrderService
+	mockOrderService := mocks.NewMockOrderService(ctrl)
+
+	// Set up expectations
+	mockOrderService.EXPECT().GetOrderByID(gomock.Any(), gomock.Any()).Return(models.Order{}, errors.New("not found"))
+
+	// Set up the controller with the mocked service
+	orderController := controllers.NewOrderController(mockOrderService)
+	r.GET("/orders/2", orderController.GetOrderByID)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/orders/2", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "error")
+}
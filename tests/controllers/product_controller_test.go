@@ -28,7 +28,7 @@ func TestPostProductsRoute(t *testing.T) {
 	mockProductService := mocks.NewMockProductService(ctrl)
 
 	// Set up expectations
-	mockProductService.EXPECT().CreateProduct(gomock.Any()).Return(nil)
+	mockProductService.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Return(nil)
 
 	// Set up the controller with the mocked service
 	productController := controllers.NewProductController(mockProductService)
@@ -101,7 +101,7 @@ func TestGetProductsRoute(t *testing.T) {
 	mockProductService := mocks.NewMockProductService(ctrl)
 
 	// Set up expectations
-	mockProductService.EXPECT().GetAllProducts().Return([]models.Product{
+	mockProductService.EXPECT().GetAllProducts(gomock.Any()).Return([]models.Product{
 		{
 			Name:          "product 1",
 			Description:   "product description 1",
@@ -147,7 +147,7 @@ func TestGetProductByIdRoute(t *testing.T) {
 	mockProductService := mocks.NewMockProductService(ctrl)
 
 	// Set up expectations
-	mockProductService.EXPECT().GetProductByID(gomock.Any()).Return(models.Product{
+	mockProductService.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).Return(models.Product{
 		Name:          "product 1",
 		Description:   "product description 1",
 		Price:         100,
@@ -183,7 +183,7 @@ func TestGetProductByIdRouteNotFound(t *testing.T) {
 	mockProductService := mocks.NewMockProductService(ctrl)
 
 	// Set up expectations
-	mockProductService.EXPECT().GetProductByID(gomock.Any()).Return(models.Product{}, errors.New("not found"))
+	mockProductService.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).Return(models.Product{}, errors.New("not found"))
 
 	// Set up the controller with the mocked service
 	productController := controllers.NewProductController(mockProductService)
@@ -212,7 +212,7 @@ func TestPutProductByIdRoute(t *testing.T) {
 	mockProductService := mocks.NewMockProductService(ctrl)
 
 	// Set up expectations
-	mockProductService.EXPECT().GetProductByID(gomock.Any()).Return(models.Product{
+	mockProductService.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).Return(models.Product{
 		Name:          "product 1",
 		Description:   "product description 1",
 		Price:         100,
@@ -221,7 +221,7 @@ func TestPutProductByIdRoute(t *testing.T) {
 		CategoryID:    1,
 	}, nil)
 
-	mockProductService.EXPECT().UpdateProduct(gomock.Any()).Return(models.Product{
+	mockProductService.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).Return(models.Product{
 		Name:          "product 1",
 		Description:   "changed product description 1",
 		Price:         100,
@@ -266,7 +266,7 @@ func TestPutProductByIdRouteBadRequest(t *testing.T) {
 	mockProductService := mocks.NewMockProductService(ctrl)
 
 	// Set up expectations
-	mockProductService.EXPECT().GetProductByID(gomock.Any()).Return(models.Product{
+	mockProductService.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).Return(models.Product{
 		Name:          "product 1",
 		Description:   "product description 1",
 		Price:         100,
@@ -311,7 +311,7 @@ func TestPutProductByIdRouteNotFound(t *testing.T) {
 	mockProductService := mocks.NewMockProductService(ctrl)
 
 	// Set up expectations
-	mockProductService.EXPECT().GetProductByID(gomock.Any()).Return(models.Product{}, errors.New("product not found"))
+	mockProductService.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).Return(models.Product{}, errors.New("product not found"))
 
 	// Set up the controller with the mocked service
 	productController := controllers.NewProductController(mockProductService)
@@ -349,7 +349,7 @@ func TestDeleteProductByIdRoute(t *testing.T) {
 	mockProductService := mocks.NewMockProductService(ctrl)
 
 	// Set up expectations
-	mockProductService.EXPECT().DeleteProduct(gomock.Any()).Return(nil)
+	mockProductService.EXPECT().DeleteProduct(gomock.Any(), gomock.Any()).Return(nil)
 
 	// Set up the controller with the mocked service
 	productController := controllers.NewProductController(mockProductService)
@@ -365,3 +365,40 @@ func TestDeleteProductByIdRoute(t *testing.T) {
 	assert.Equal(t, http.StatusOK, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "deleted")
 }
+
+func TestRestoreProductByIdRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the ProductService
+	mockProductService := mocks.NewMockProductService(ctrl)
+
+	// Set up expectations
+	mockProductService.EXPECT().RestoreProduct(gomock.Any(), uint(1)).Return(models.Product{
+		ID:            1,
+		Name:          "product 1",
+		Description:   "product description 1",
+		Price:         100,
+		StockQuantity: 10,
+		IsActive:      true,
+		CategoryID:    1,
+	}, nil)
+
+	// Set up the controller with the mocked service
+	productController := controllers.NewProductController(mockProductService)
+	r.POST("/products/:id/restore", productController.RestoreProduct)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodPost, "/products/1/restore", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"deleted_at":null`)
+}
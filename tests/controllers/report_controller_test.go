@@ -0,0 +1,473 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/controllers"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProductReportRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+
+	// The query parameters are parsed into the report query
+	minPrice := 10.5
+	maxStock := 20
+	mockReportService.EXPECT().GenerateProductReport(gomock.Any(), models.ReportQuery{
+		Name:               "lap",
+		CategoryID:         2,
+		IncludeDescendants: true,
+		MinPrice:           &minPrice,
+		MaxStock:           &maxStock,
+		SortBy:             "price",
+		SortOrder:          "desc",
+		Page:               2,
+		PageSize:           10,
+		Strategy:           models.ReportStrategyGoroutines,
+	}).Return(models.ProductReport{TotalProducts: 12, Products: []models.Product{}}, models.ReportCacheStatus{}, nil)
+
+	reportController := controllers.NewReportController(mockReportService)
+	r.GET("/reports/products", reportController.GetProductReport)
+
+	req, _ := http.NewRequest(http.MethodGet, "/reports/products?name=lap&category_id=2&include_descendants=true&min_price=10.5&max_stock=20&sort_by=price&sort_order=desc&page=2&is_optimized=true", nil)
+	r.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"total_products":12,"total_stock":0,"avg_price":0,"products":[]}`, recorder.Body.String())
+}
+
+func TestGetProductReportRouteInvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+	reportController := controllers.NewReportController(mockReportService)
+
+	// The report is not generated for invalid parameters, sort_order in particular never reaches the SQL
+	for _, query := range []string{"sort_order=asc%3B%20DROP%20TABLE%20products", "sort_by=description", "page=0", "page_size=ten", "page_size=101", "strategy=parallel", "min_price=-1", "category_id=abc"} {
+		r := gin.Default()
+		recorder := httptest.NewRecorder()
+		r.GET("/reports/products", reportController.GetProductReport)
+
+		req, _ := http.NewRequest(http.MethodGet, "/reports/products?"+query, nil)
+		r.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, query)
+		assert.Contains(t, recorder.Body.String(), "invalid report query", query)
+	}
+}
+
+func TestGetProductReportRouteStrategy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+	reportController := controllers.NewReportController(mockReportService)
+
+	// strategy takes precedence over is_optimized, which defaults to the sequential strategy
+	for target, strategy := range map[string]string{
+		"/reports/products":                                       models.ReportStrategySequential,
+		"/reports/products?is_optimized=true":                     models.ReportStrategyGoroutines,
+		"/reports/products?strategy=single_query":                 models.ReportStrategySingleQuery,
+		"/reports/products?strategy=sequential&is_optimized=true": models.ReportStrategySequential,
+	} {
+		mockReportService.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ *gin.Context, query models.ReportQuery) (models.ProductReport, models.ReportCacheStatus, error) {
+				assert.Equal(t, strategy, query.Strategy, target)
+				return models.ProductReport{}, models.ReportCacheStatus{}, nil
+			})
+		r := gin.Default()
+		recorder := httptest.NewRecorder()
+		r.GET("/reports/products", reportController.GetProductReport)
+
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		r.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code, target)
+	}
+}
+
+func TestGetProductReportRouteGenerationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+	reportController := controllers.NewReportController(mockReportService)
+
+	for err, status := range map[error]int{
+		context.Canceled:                 499,
+		context.DeadlineExceeded:         http.StatusGatewayTimeout,
+		fmt.Errorf("connection refused"): http.StatusInternalServerError,
+	} {
+		mockReportService.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).Return(models.ProductReport{}, models.ReportCacheStatus{}, err)
+		r := gin.Default()
+		recorder := httptest.NewRecorder()
+		r.GET("/reports/products", reportController.GetProductReport)
+
+		req, _ := http.NewRequest(http.MethodGet, "/reports/products", nil)
+		r.ServeHTTP(recorder, req)
+
+		assert.Equal(t, status, recorder.Code, err.Error())
+	}
+}
+
+func TestGetProductReportRouteExportCSV(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+
+	// The filters and sorting apply to the export, which streams every row rather than the report
+	mockReportService.EXPECT().ExportProductReport(gomock.Any(), models.ReportQuery{
+		Name:      "lap",
+		SortBy:    "price",
+		SortOrder: "desc",
+		Page:      1,
+		PageSize:  10,
+		Strategy:  models.ReportStrategySequential,
+	}, gomock.Any()).DoAndReturn(func(ctx *gin.Context, query models.ReportQuery, write func(row models.ProductExportRow) error) error {
+		assert.Nil(t, write(models.ProductExportRow{ID: 3, Name: "Laptop", CategoryName: "Electronics", Price: 999.9, StockQuantity: 4}))
+		return write(models.ProductExportRow{ID: 7, Name: "Laptop bag", Price: 25, StockQuantity: 12})
+	})
+
+	reportController := controllers.NewReportController(mockReportService)
+	r.GET("/reports/products", reportController.GetProductReport)
+
+	req, _ := http.NewRequest(http.MethodGet, "/reports/products?format=csv&name=lap&sort_by=price&sort_order=desc", nil)
+	r.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename=product-report-\d{4}-\d{2}-\d{2}\.csv$`, recorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "ID,Name,Category,Price,Stock Quantity\n3,Laptop,Electronics,999.9,4\n7,Laptop bag,,25,12\n", recorder.Body.String())
+}
+
+func TestGetProductReportRouteNegotiatesFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+	reportController := controllers.NewReportController(mockReportService)
+
+	// format takes precedence over Accept, and types the report is not available in get JSON
+	for _, test := range []struct {
+		target, accept, contentType string
+	}{
+		{"/reports/products?format=xlsx", "application/pdf", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{"/reports/products", "application/pdf", "application/pdf"},
+		{"/reports/products", "text/csv", "text/csv"},
+		{"/reports/products?format=json", "text/csv", "application/json; charset=utf-8"},
+		{"/reports/products", "text/html", "application/json; charset=utf-8"},
+		{"/reports/products", "", "application/json; charset=utf-8"},
+	} {
+		if test.contentType == "application/json; charset=utf-8" {
+			mockReportService.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).Return(models.ProductReport{Products: []models.Product{}}, models.ReportCacheStatus{}, nil)
+		} else {
+			mockReportService.EXPECT().ExportProductReport(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		}
+		r := gin.Default()
+		recorder := httptest.NewRecorder()
+		r.GET("/reports/products", reportController.GetProductReport)
+
+		req, _ := http.NewRequest(http.MethodGet, test.target, nil)
+		req.Header.Set("Accept", test.accept)
+		r.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code, test.target+" "+test.accept)
+		assert.Equal(t, test.contentType, recorder.Header().Get("Content-Type"), test.target+" "+test.accept)
+		assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+	}
+}
+
+func TestGetProductReportRouteExportErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+	reportController := controllers.NewReportController(mockReportService)
+
+	// Unknown formats are rejected
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+	r.GET("/reports/products", reportController.GetProductReport)
+	req, _ := http.NewRequest(http.MethodGet, "/reports/products?format=ods", nil)
+	r.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid report query")
+
+	// A failure before the file is sent is answered with a JSON error rather than an attachment
+	mockReportService.EXPECT().ExportProductReport(gomock.Any(), gomock.Any(), gomock.Any()).Return(context.DeadlineExceeded)
+	r = gin.Default()
+	recorder = httptest.NewRecorder()
+	r.GET("/reports/products", reportController.GetProductReport)
+	req, _ = http.NewRequest(http.MethodGet, "/reports/products?format=pdf", nil)
+	r.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+	assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Empty(t, recorder.Header().Get("Content-Disposition"))
+	// Instances stream a few exports at a time, the others are told to retry
+	mockReportService.EXPECT().ExportProductReport(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.ErrTooManyExports)
+	r = gin.Default()
+	recorder = httptest.NewRecorder()
+	r.GET("/reports/products", reportController.GetProductReport)
+	req, _ = http.NewRequest(http.MethodGet, "/reports/products?format=csv", nil)
+	r.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "10", recorder.Header().Get("Retry-After"))
+}
+
+func TestGetProductReportRouteExportRowLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+	reportController := controllers.NewReportController(mockReportService)
+
+	// A PDF is built in memory, the export stops at the first row over the limit
+	var written int
+	mockReportService.EXPECT().ExportProductReport(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx *gin.Context, query models.ReportQuery, write func(row models.ProductExportRow) error) error {
+			for i := 1; i <= 20000; i++ {
+				if err := write(models.ProductExportRow{ID: uint(i), Name: "Product"}); err != nil {
+					return err
+				}
+				written = i
+			}
+			return nil
+		})
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+	r.GET("/reports/products", reportController.GetProductReport)
+	req, _ := http.NewRequest(http.MethodGet, "/reports/products?format=pdf", nil)
+	r.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "pdf exports are limited to 10000 rows")
+	assert.Equal(t, 10000, written)
+	assert.Empty(t, recorder.Header().Get("Content-Disposition"))
+}
+
+func TestGetTopCustomersReportRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the ReportService
+	mockReportService := mocks.NewMockReportService(ctrl)
+
+	// Set up expectations
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.Local)
+	mockReportService.EXPECT().GenerateTopCustomersReport(gomock.Any(), models.TopCustomersQuery{From: &from, To: &to, Limit: 10}).Return([]models.TopCustomer{
+		{ID: 1, Name: "Jane Doe", Email: "jane@example.com", TotalOrders: 3, TotalSpent: 1500},
+	}, models.ReportCacheStatus{}, nil)
+
+	// Set up the controller with the mocked service
+	reportController := controllers.NewReportController(mockReportService)
+	r.GET("/reports/top-customers", reportController.GetTopCustomersReport)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/reports/top-customers?from=2024-01-01&to=2024-12-31", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"total_spent":1500`)
+	assert.Equal(t, "elabram-reports; fwd=miss", recorder.Header().Get("Cache-Status"))
+	assert.Empty(t, recorder.Header().Get("Age"))
+}
+
+func TestGetProductSalesReportRouteInvalidDateRange(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the ReportService
+	mockReportService := mocks.NewMockReportService(ctrl)
+
+	// The query is rejected before the report is generated
+	mockReportService.EXPECT().GenerateProductSalesReport(gomock.Any(), gomock.Any()).Times(0)
+
+	// Set up the controller with the mocked service
+	reportController := controllers.NewReportController(mockReportService)
+	r.GET("/reports/product-sales", reportController.GetProductSalesReport)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/reports/product-sales?from=yesterday", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid date range")
+}
+
+func TestGetProductSalesReportRouteParsesQuery(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+
+	// The query reaches the service parsed, with the exclusive upper bound of the date range
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.Local)
+	mockReportService.EXPECT().GenerateProductSalesReport(gomock.Any(), models.ProductSalesQuery{
+		From: &from, To: &to, Name: "chair", CategoryID: 4, IncludeDescendants: true, Page: 2, PageSize: 20,
+	}).Return(models.ProductSalesPageable{Products: []models.ProductSales{}, Page: 2}, models.ReportCacheStatus{}, nil)
+
+	reportController := controllers.NewReportController(mockReportService)
+	r.GET("/reports/product-sales", reportController.GetProductSalesReport)
+
+	req, _ := http.NewRequest(http.MethodGet, "/reports/product-sales?from=2024-03-01&to=2024-03-31&name=chair&category_id=4&include_descendants=true&page=2&page_size=20", nil)
+	r.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"page":2`)
+}
+
+func TestGetProductSalesReportRouteInvalidPage(t *testing.T) {
+	r := gin.Default()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReportService := mocks.NewMockReportService(ctrl)
+	mockReportService.EXPECT().GenerateProductSalesReport(gomock.Any(), gomock.Any()).Times(0)
+
+	reportController := controllers.NewReportController(mockReportService)
+	r.GET("/reports/product-sales", reportController.GetProductSalesReport)
+
+	for query, message := range map[string]string{
+		"page=0":        "page must be an integer of at least 1",
+		"page_size=101": "page_size must be at most 100",
+	} {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/reports/product-sales?"+query, nil)
+		r.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, query)
+		assert.Contains(t, recorder.Body.String(), message, query)
+	}
+}
+
+func TestGetSalesTimeseriesReportRoute(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the ReportService
+	mockReportService := mocks.NewMockReportService(ctrl)
+
+	// Set up expectations
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2024, time.January, 3, 0, 0, 0, 0, time.Local)
+	mockReportService.EXPECT().GenerateSalesTimeseriesReport(gomock.Any(), models.SalesTimeseriesQuery{From: &from, To: &to, Interval: "day"}).Return(models.SalesTimeseriesReport{
+		From:           "2024-01-01",
+		To:             "2024-01-02",
+		Interval:       "day",
+		TotalRevenue:   250,
+		TotalUnitsSold: 5,
+		Points: []models.SalesTimeseriesPoint{
+			{Period: "2024-01-01", Revenue: 250, UnitsSold: 5, Orders: 2},
+			{Period: "2024-01-02"},
+		},
+	}, models.ReportCacheStatus{Hit: true, Age: 42 * time.Second, TTL: 258 * time.Second}, nil)
+
+	// Set up the controller with the mocked service
+	reportController := controllers.NewReportController(mockReportService)
+	r.GET("/reports/sales-timeseries", reportController.GetSalesTimeseriesReport)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/reports/sales-timeseries?from=2024-01-01&to=2024-01-02&interval=day", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `{"period":"2024-01-02","revenue":0,"units_sold":0,"orders":0}`)
+	assert.Equal(t, "elabram-reports; hit; ttl=258", recorder.Header().Get("Cache-Status"))
+	assert.Equal(t, "42", recorder.Header().Get("Age"))
+}
+
+func TestGetSalesTimeseriesReportRouteInvalidInterval(t *testing.T) {
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the ReportService
+	mockReportService := mocks.NewMockReportService(ctrl)
+
+	// The query is rejected before the report is generated
+	mockReportService.EXPECT().GenerateSalesTimeseriesReport(gomock.Any(), gomock.Any()).Times(0)
+
+	// Set up the controller with the mocked service
+	reportController := controllers.NewReportController(mockReportService)
+	r.GET("/reports/sales-timeseries", reportController.GetSalesTimeseriesReport)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/reports/sales-timeseries?interval=hour", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid interval")
+}
+
+func TestGetSalesTimeseriesReportRouteInvalidCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	recorder := httptest.NewRecorder()
+
+	// Create a new mock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Mock the ReportService
+	mockReportService := mocks.NewMockReportService(ctrl)
+
+	// The query is rejected before the report is generated
+	mockReportService.EXPECT().GenerateSalesTimeseriesReport(gomock.Any(), gomock.Any()).Times(0)
+
+	// Set up the controller with the mocked service
+	reportController := controllers.NewReportController(mockReportService)
+	r.GET("/reports/sales-timeseries", reportController.GetSalesTimeseriesReport)
+
+	// Create a new request
+	req, _ := http.NewRequest(http.MethodGet, "/reports/sales-timeseries?category_id=abc", nil)
+
+	// Perform the request
+	r.ServeHTTP(recorder, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid category")
+}
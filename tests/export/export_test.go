@@ -0,0 +1,62 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+var columns = []export.Column{{Title: "ID", Width: 10}, {Title: "Name", Width: 40}, {Title: "Price", Width: 12}}
+
+func TestCSVWriter(t *testing.T) {
+	var output bytes.Buffer
+	writer, err := export.NewWriter(export.FormatCSV, &output, "Products", columns)
+	assert.Nil(t, err)
+
+	assert.Nil(t, writer.WriteRow(uint(1), "Desk, oak", 120.5))
+	// Formulas are not evaluated by the spreadsheets opening the file
+	assert.Nil(t, writer.WriteRow(uint(2), "=HYPERLINK(\"http://example.com\")", 9.99))
+	assert.Nil(t, writer.Close())
+
+	assert.Equal(t, "ID,Name,Price\n1,\"Desk, oak\",120.5\n2,\"'=HYPERLINK(\"\"http://example.com\"\")\",9.99\n", output.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var output bytes.Buffer
+	writer, err := export.NewWriter(export.FormatXLSX, &output, "Products", columns)
+	assert.Nil(t, err)
+
+	assert.Nil(t, writer.WriteRow(uint(1), "Desk", 120.5))
+	assert.Nil(t, writer.WriteRow(uint(2), "Lamp", 9.99))
+	assert.Nil(t, writer.Close())
+
+	file, err := excelize.OpenReader(&output)
+	assert.Nil(t, err)
+	defer file.Close()
+	rows, err := file.GetRows("Products")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"ID", "Name", "Price"}, {"1", "Desk", "120.5"}, {"2", "Lamp", "9.99"}}, rows)
+}
+
+func TestPDFWriter(t *testing.T) {
+	var output bytes.Buffer
+	writer, err := export.NewWriter(export.FormatPDF, &output, "Products", columns)
+	assert.Nil(t, err)
+
+	// Enough rows for several pages, with text longer than its column
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, writer.WriteRow(uint(i), "Café table with a name much longer than the column it is written in", 120.5))
+	}
+	assert.Nil(t, writer.Close())
+
+	assert.True(t, bytes.HasPrefix(output.Bytes(), []byte("%PDF-")))
+	assert.Contains(t, output.String(), "/Count 4")
+}
+
+func TestNewWriterUnknownFormat(t *testing.T) {
+	_, err := export.NewWriter("ods", &bytes.Buffer{}, "Products", columns)
+	assert.NotNil(t, err)
+}
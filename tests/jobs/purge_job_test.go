@@ -0,0 +1,95 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/jobs"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepository := mocks.NewMockProductRepository(ctrl)
+	mockCategoryRepository := mocks.NewMockCategoryRepository(ctrl)
+
+	retention := 48 * time.Hour
+	expectedCutoff := time.Now().Add(-retention)
+	assertCutoff := func(deletedBefore time.Time) {
+		assert.WithinDuration(t, expectedCutoff, deletedBefore, time.Minute)
+	}
+
+	gomock.InOrder(
+		mockProductRepository.EXPECT().PurgeDeletedProducts(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, deletedBefore time.Time) (int64, error) {
+			assertCutoff(deletedBefore)
+			return 2, nil
+		}),
+		mockCategoryRepository.EXPECT().PurgeDeletedCategories(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, deletedBefore time.Time) (int64, error) {
+			assertCutoff(deletedBefore)
+			return 1, nil
+		}),
+	)
+
+	err := jobs.Purge(context.Background(), mockProductRepository, mockCategoryRepository, retention, slog.Default())
+
+	assert.Nil(t, err)
+}
+
+func TestPurgeStopsOnProductError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepository := mocks.NewMockProductRepository(ctrl)
+	mockCategoryRepository := mocks.NewMockCategoryRepository(ctrl)
+
+	mockProductRepository.EXPECT().PurgeDeletedProducts(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("connection lost"))
+
+	err := jobs.Purge(context.Background(), mockProductRepository, mockCategoryRepository, time.Hour, slog.Default())
+
+	assert.EqualError(t, err, "connection lost")
+}
+
+func TestStartPurgeJobStopsWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepository := mocks.NewMockProductRepository(ctrl)
+	mockCategoryRepository := mocks.NewMockCategoryRepository(ctrl)
+
+	// The job purges once at startup, then only every interval until it is stopped
+	purged := make(chan struct{})
+	mockProductRepository.EXPECT().PurgeDeletedProducts(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+	mockCategoryRepository.EXPECT().PurgeDeletedCategories(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, time.Time) (int64, error) {
+		close(purged)
+		return 0, nil
+	}).Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var background sync.WaitGroup
+	jobs.StartPurgeJob(ctx, &background, mockProductRepository, mockCategoryRepository, time.Hour, 50*time.Millisecond, slog.Default())
+	<-purged
+	cancel()
+
+	// No further run once the context is done
+	time.Sleep(150 * time.Millisecond)
+
+	// The job has returned
+	stopped := make(chan struct{})
+	go func() {
+		background.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("purge job still running after its context is done")
+	}
+}
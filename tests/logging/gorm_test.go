@@ -0,0 +1,79 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func decodeLogLine(t *testing.T, logs *bytes.Buffer) map[string]interface{} {
+	var line map[string]interface{}
+	assert.Nil(t, json.Unmarshal(logs.Bytes(), &line))
+	return line
+}
+
+func TestGormLoggerUsesRequestLogger(t *testing.T) {
+	var baseLogs, requestLogs bytes.Buffer
+	gormLogger := logging.NewGormLogger(logging.New(&baseLogs, "debug"), time.Second)
+	ctx := logging.NewContext(context.Background(), logging.New(&requestLogs, "debug").With("request_id", "abc-123"))
+
+	gormLogger.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 1 }, nil)
+
+	assert.Empty(t, baseLogs.String())
+	line := decodeLogLine(t, &requestLogs)
+	assert.Equal(t, "sql query", line["msg"])
+	assert.Equal(t, "DEBUG", line["level"])
+	assert.Equal(t, "SELECT 1", line["sql"])
+	assert.Equal(t, "abc-123", line["request_id"])
+}
+
+func TestGormLoggerSlowQuery(t *testing.T) {
+	var logs bytes.Buffer
+	gormLogger := logging.NewGormLogger(logging.New(&logs, "info"), 10*time.Millisecond)
+
+	gormLogger.Trace(context.Background(), time.Now().Add(-time.Second), func() (string, int64) { return "SELECT SLEEP(1)", 1 }, nil)
+
+	line := decodeLogLine(t, &logs)
+	assert.Equal(t, "slow sql query", line["msg"])
+	assert.Equal(t, "WARN", line["level"])
+}
+
+func TestGormLoggerFailedQuery(t *testing.T) {
+	var logs bytes.Buffer
+	gormLogger := logging.NewGormLogger(logging.New(&logs, "info"), time.Second)
+
+	// A missing record is an expected outcome, not a failure
+	gormLogger.Trace(context.Background(), time.Now(), func() (string, int64) { return "SELECT 1", 0 }, gorm.ErrRecordNotFound)
+	assert.Empty(t, logs.String())
+
+	gormLogger.Trace(context.Background(), time.Now(), func() (string, int64) { return "SELECT 1", 0 }, errors.New("connection refused"))
+	line := decodeLogLine(t, &logs)
+	assert.Equal(t, "sql query failed", line["msg"])
+	assert.Equal(t, "connection refused", line["error"])
+}
+
+func TestRepositoryQueriesCarryRequestLogger(t *testing.T) {
+	var baseLogs, requestLogs bytes.Buffer
+	gormLogger := logging.NewGormLogger(logging.New(&baseLogs, "debug"), time.Second)
+
+	// Dry run builds the statements without a database connection
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, Logger: gormLogger})
+	assert.Nil(t, err)
+
+	ctx := logging.NewContext(context.Background(), logging.New(&requestLogs, "debug").With("request_id", "abc-123"))
+	repositories.NewProductRepository(db).GetProductByID(ctx, 1)
+
+	assert.Empty(t, baseLogs.String())
+	line := decodeLogLine(t, &requestLogs)
+	assert.Equal(t, "abc-123", line["request_id"])
+	assert.Contains(t, line["sql"], "SELECT * FROM `products` WHERE `products`.`id` = 1")
+}
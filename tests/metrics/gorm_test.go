@@ -0,0 +1,51 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   uint
+	Name string
+}
+
+// observedQueries returns how many statements were observed for the operation and table
+func observedQueries(t *testing.T, operation string, table string) uint64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.Nil(t, err)
+	for _, family := range families {
+		if family.GetName() != "elabram_db_query_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["operation"] == operation && labels["table"] == table {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestGormPluginObservesQueries(t *testing.T) {
+	// Dry run builds the statements without a database connection
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Nil(t, err)
+	assert.Nil(t, db.Use(metrics.NewGormPlugin()))
+
+	db.Find(&[]widget{})
+	db.Find(&[]widget{})
+	db.Create(&widget{Name: "widget"})
+
+	assert.Equal(t, uint64(2), observedQueries(t, "query", "widgets"))
+	assert.Equal(t, uint64(1), observedQueries(t, "create", "widgets"))
+}
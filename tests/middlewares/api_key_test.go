@@ -0,0 +1,67 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockAuthService.EXPECT().ParseAccessToken("editor-token").Return(models.AuthUser{ID: 1, Role: models.RoleEditor}, nil).AnyTimes()
+	mockAPIKeyService := mocks.NewMockAPIKeyService(ctrl)
+	mockAPIKeyService.EXPECT().Authenticate(gomock.Any(), "reader-key").Return(models.APIKey{ID: 1, Scopes: []string{models.ScopeProductsRead}}, nil).AnyTimes()
+	mockAPIKeyService.EXPECT().Authenticate(gomock.Any(), "writer-key").Return(models.APIKey{ID: 2, Scopes: []string{models.ScopeProductsWrite}}, nil).AnyTimes()
+	mockAPIKeyService.EXPECT().Authenticate(gomock.Any(), "expired-key").Return(models.APIKey{}, models.ErrInvalidAPIKey).AnyTimes()
+
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+
+	r := gin.New()
+	r.Use(middlewares.Authenticate(mockAuthService), middlewares.AuthenticateAPIKey(mockAPIKeyService))
+	r.GET("/products", middlewares.RequireScope(models.ScopeProductsRead), ok)
+	r.POST("/products", middlewares.Authorize(models.RoleEditor, models.ScopeProductsWrite), ok)
+
+	cases := []struct {
+		name   string
+		method string
+		token  string
+		apiKey string
+		status int
+	}{
+		{"anonymous read", http.MethodGet, "", "", http.StatusOK},
+		{"read with read scope", http.MethodGet, "", "reader-key", http.StatusOK},
+		{"read without read scope", http.MethodGet, "", "writer-key", http.StatusForbidden},
+		{"expired key", http.MethodGet, "", "expired-key", http.StatusUnauthorized},
+		{"anonymous write", http.MethodPost, "", "", http.StatusUnauthorized},
+		{"write with write scope", http.MethodPost, "", "writer-key", http.StatusOK},
+		{"write without write scope", http.MethodPost, "", "reader-key", http.StatusForbidden},
+		{"editor with read key", http.MethodPost, "editor-token", "reader-key", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest(c.method, "/products", nil)
+			if c.token != "" {
+				req.Header.Set("Authorization", "Bearer "+c.token)
+			}
+			if c.apiKey != "" {
+				req.Header.Set("X-API-Key", c.apiKey)
+			}
+
+			r.ServeHTTP(recorder, req)
+
+			assert.Equal(t, c.status, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAuthRouter(t *testing.T) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockAuthService.EXPECT().ParseAccessToken("viewer-token").Return(models.AuthUser{ID: 1, Role: models.RoleViewer}, nil).AnyTimes()
+	mockAuthService.EXPECT().ParseAccessToken("editor-token").Return(models.AuthUser{ID: 2, Role: models.RoleEditor}, nil).AnyTimes()
+	mockAuthService.EXPECT().ParseAccessToken("admin-token").Return(models.AuthUser{ID: 3, Role: models.RoleAdmin}, nil).AnyTimes()
+	mockAuthService.EXPECT().ParseAccessToken("expired-token").Return(models.AuthUser{}, models.ErrInvalidToken).AnyTimes()
+
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+
+	r := gin.New()
+	r.Use(middlewares.Authenticate(mockAuthService))
+	r.GET("/public", ok)
+	r.GET("/listing", middlewares.RequireRoleWhenQuery("include_deleted", "true", models.RoleAdmin), ok)
+	r.POST("/write", middlewares.RequireRole(models.RoleEditor), ok)
+	return r
+}
+
+func TestRequireRole(t *testing.T) {
+	r := setupAuthRouter(t)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{"anonymous public read", http.MethodGet, "/public", "", http.StatusOK},
+		{"invalid token is rejected", http.MethodGet, "/public", "expired-token", http.StatusUnauthorized},
+		{"anonymous write", http.MethodPost, "/write", "", http.StatusUnauthorized},
+		{"viewer write", http.MethodPost, "/write", "viewer-token", http.StatusForbidden},
+		{"editor write", http.MethodPost, "/write", "editor-token", http.StatusOK},
+		{"admin write", http.MethodPost, "/write", "admin-token", http.StatusOK},
+		{"anonymous listing", http.MethodGet, "/listing", "", http.StatusOK},
+		{"editor deleted listing", http.MethodGet, "/listing?include_deleted=true", "editor-token", http.StatusForbidden},
+		{"admin deleted listing", http.MethodGet, "/listing?include_deleted=true", "admin-token", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest(c.method, c.path, nil)
+			if c.token != "" {
+				req.Header.Set("Authorization", "Bearer "+c.token)
+			}
+
+			r.ServeHTTP(recorder, req)
+
+			assert.Equal(t, c.status, recorder.Code)
+		})
+	}
+}
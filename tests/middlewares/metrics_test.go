@@ -0,0 +1,50 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsLabelsByRouteTemplate(t *testing.T) {
+	r := gin.New()
+	r.Use(middlewares.Metrics())
+	r.GET("/widgets/:id", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	for _, path := range []string{"/widgets/1", "/widgets/2", "/unknown"} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	r.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `elabram_http_request_duration_seconds_count{method="GET",route="/widgets/:id",status="200"} 2`)
+	assert.Contains(t, recorder.Body.String(), `elabram_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+}
+
+func TestMetricsLabelsUnknownMethodsAsOther(t *testing.T) {
+	r := gin.New()
+	r.Use(middlewares.Metrics())
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	for _, method := range []string{"BREW", "PROPFIND", "X-RANDOM-1"} {
+		req, _ := http.NewRequest(method, "/gadgets", nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	r.ServeHTTP(recorder, req)
+
+	assert.Contains(t, recorder.Body.String(), `elabram_http_request_duration_seconds_count{method="other",route="unmatched",status="404"} 3`)
+	assert.NotContains(t, recorder.Body.String(), `method="BREW"`)
+}
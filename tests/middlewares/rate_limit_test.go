@@ -0,0 +1,124 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/ratelimit"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRateLimitRouter(t *testing.T) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockAuthService.EXPECT().ParseAccessToken("user-token").Return(models.AuthUser{ID: 1, Role: models.RoleViewer}, nil).AnyTimes()
+
+	limits := map[string]ratelimit.Limit{
+		middlewares.DefaultRateLimitGroup: {Requests: 2, Window: time.Minute},
+		"reports":                         {Requests: 1, Window: time.Minute},
+	}
+
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+
+	r := gin.New()
+	r.Use(middlewares.Authenticate(mockAuthService), middlewares.RateLimit(ratelimit.NewMemoryLimiter(), limits))
+	r.GET("/products/:id", ok)
+	r.GET("/reports/products", ok)
+	return r
+}
+
+func performRequest(r *gin.Engine, path string, token string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	r.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	r := setupRateLimitRouter(t)
+
+	recorder := performRequest(r, "/products/1", "")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", recorder.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", recorder.Header().Get("X-RateLimit-Reset"))
+}
+
+func TestRateLimitExceeded(t *testing.T) {
+	r := setupRateLimitRouter(t)
+
+	performRequest(r, "/products/1", "")
+	performRequest(r, "/products/2", "")
+	recorder := performRequest(r, "/products/3", "")
+
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+}
+
+func TestRateLimitPerGroupAndClient(t *testing.T) {
+	r := setupRateLimitRouter(t)
+
+	// The reports group has its own, stricter limit
+	assert.Equal(t, http.StatusOK, performRequest(r, "/reports/products", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, performRequest(r, "/reports/products", "").Code)
+
+	// Other groups and other clients from the same IP are counted separately
+	assert.Equal(t, http.StatusOK, performRequest(r, "/products/1", "").Code)
+	assert.Equal(t, http.StatusOK, performRequest(r, "/reports/products", "user-token").Code)
+}
+
+func TestRateLimitIPBeforeAuthentication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Invalid tokens are rejected by Authenticate, the limit by IP still counts them
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockAuthService.EXPECT().ParseAccessToken("forged-token").Return(models.AuthUser{}, models.ErrInvalidToken).Times(2)
+	limits := map[string]ratelimit.Limit{middlewares.IPRateLimitGroup: {Requests: 2, Window: time.Minute}}
+
+	r := gin.New()
+	r.Use(middlewares.RateLimitIP(ratelimit.NewMemoryLimiter(), limits), middlewares.Authenticate(mockAuthService))
+	r.GET("/products/:id", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	assert.Equal(t, http.StatusUnauthorized, performRequest(r, "/products/1", "forged-token").Code)
+	assert.Equal(t, http.StatusUnauthorized, performRequest(r, "/products/1", "forged-token").Code)
+	recorder := performRequest(r, "/products/1", "forged-token")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+}
+
+func TestRateLimitIPIgnoresUntrustedForwardedFor(t *testing.T) {
+	limits := map[string]ratelimit.Limit{middlewares.IPRateLimitGroup: {Requests: 2, Window: time.Minute}}
+
+	r := gin.New()
+	assert.Nil(t, r.SetTrustedProxies(nil))
+	r.Use(middlewares.RateLimitIP(ratelimit.NewMemoryLimiter(), limits))
+	r.GET("/products/:id", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	// A client spoofing X-Forwarded-For is still limited by the address it connects from
+	codes := []int{}
+	for _, forwardedFor := range []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"} {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/products/1", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		r.ServeHTTP(recorder, req)
+		codes = append(codes, recorder.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+}
@@ -0,0 +1,94 @@
+package middlewares_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/logging"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/middlewares"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupLoggingRouter(logs *bytes.Buffer) *gin.Engine {
+	r := gin.New()
+	r.Use(middlewares.RequestID(logging.New(logs, "debug")), middlewares.AccessLog(), middlewares.Recovery())
+	r.GET("/products/:id", func(ctx *gin.Context) {
+		logging.FromContext(ctx.Request.Context()).Debug("loading product")
+		ctx.Status(http.StatusOK)
+	})
+	r.GET("/panic", func(ctx *gin.Context) {
+		panic("boom")
+	})
+	return r
+}
+
+func decodeLogLines(t *testing.T, logs *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	decoder := json.NewDecoder(logs)
+	for decoder.More() {
+		var line map[string]interface{}
+		assert.Nil(t, decoder.Decode(&line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestRequestIDIsPropagated(t *testing.T) {
+	var logs bytes.Buffer
+	r := setupLoggingRouter(&logs)
+	recorder := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/products/1", nil)
+	req.Header.Set(middlewares.RequestIDHeader, "abc-123")
+	r.ServeHTTP(recorder, req)
+
+	assert.Equal(t, "abc-123", recorder.Header().Get(middlewares.RequestIDHeader))
+
+	lines := decodeLogLines(t, &logs)
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Equal(t, "abc-123", line["request_id"])
+	}
+	assert.Equal(t, "loading product", lines[0]["msg"])
+
+	accessLog := lines[1]
+	assert.Equal(t, "request served", accessLog["msg"])
+	assert.Equal(t, "GET", accessLog["method"])
+	assert.Equal(t, "/products/:id", accessLog["route"])
+	assert.Equal(t, float64(http.StatusOK), accessLog["status"])
+	assert.Contains(t, accessLog, "latency_ms")
+}
+
+func TestRequestIDIsAssigned(t *testing.T) {
+	var logs bytes.Buffer
+	r := setupLoggingRouter(&logs)
+	recorder := httptest.NewRecorder()
+
+	// Unsafe IDs are replaced rather than echoed back
+	req, _ := http.NewRequest(http.MethodGet, "/products/1", nil)
+	req.Header.Set(middlewares.RequestIDHeader, "bad id\nwith newline")
+	r.ServeHTTP(recorder, req)
+
+	requestID := recorder.Header().Get(middlewares.RequestIDHeader)
+	assert.Len(t, requestID, 32)
+	assert.Equal(t, requestID, decodeLogLines(t, &logs)[0]["request_id"])
+}
+
+func TestRecoveryLogsPanic(t *testing.T) {
+	var logs bytes.Buffer
+	r := setupLoggingRouter(&logs)
+	recorder := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/panic", nil)
+	r.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	lines := decodeLogLines(t, &logs)
+	assert.Equal(t, "panic recovered", lines[0]["msg"])
+	assert.Equal(t, "ERROR", lines[1]["level"])
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./cmd/services/cache_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCacheService is a mock of CacheService interface.
+type MockCacheService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheServiceMockRecorder
+}
+
+// MockCacheServiceMockRecorder is the mock recorder for MockCacheService.
+type MockCacheServiceMockRecorder struct {
+	mock *MockCacheService
+}
+
+// NewMockCacheService creates a new mock instance.
+func NewMockCacheService(ctrl *gomock.Controller) *MockCacheService {
+	mock := &MockCacheService{ctrl: ctrl}
+	mock.recorder = &MockCacheServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheService) EXPECT() *MockCacheServiceMockRecorder {
+	return m.recorder
+}
+
+// PurgeReports mocks base method.
+func (m *MockCacheService) PurgeReports(ctx context.Context, tag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeReports", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeReports indicates an expected call of PurgeReports.
+func (mr *MockCacheServiceMockRecorder) PurgeReports(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeReports", reflect.TypeOf((*MockCacheService)(nil).PurgeReports), ctx, tag)
+}
@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ndkode/elabram-backend-recruitment/cmd/models"
@@ -35,73 +37,146 @@ func (m *MockCategoryRepository) EXPECT() *MockCategoryRepositoryMockRecorder {
 }
 
 // CreateCategory mocks base method.
-func (m *MockCategoryRepository) CreateCategory(category *models.Category) error {
+func (m *MockCategoryRepository) CreateCategory(ctx context.Context, category *models.Category) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCategory", category)
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, category)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateCategory indicates an expected call of CreateCategory.
-func (mr *MockCategoryRepositoryMockRecorder) CreateCategory(category interface{}) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) CreateCategory(ctx, category interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryRepository)(nil).CreateCategory), category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryRepository)(nil).CreateCategory), ctx, category)
+}
+
+// DeactivateProductsAndDeleteCategory mocks base method.
+func (m *MockCategoryRepository) DeactivateProductsAndDeleteCategory(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateProductsAndDeleteCategory", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateProductsAndDeleteCategory indicates an expected call of DeactivateProductsAndDeleteCategory.
+func (mr *MockCategoryRepositoryMockRecorder) DeactivateProductsAndDeleteCategory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateProductsAndDeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).DeactivateProductsAndDeleteCategory), ctx, id)
 }
 
 // DeleteCategory mocks base method.
-func (m *MockCategoryRepository) DeleteCategory(id uint) error {
+func (m *MockCategoryRepository) DeleteCategory(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", id)
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockCategoryRepositoryMockRecorder) DeleteCategory(id interface{}) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) DeleteCategory(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteCategory), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteCategory), ctx, id)
 }
 
 // GetAllCategories mocks base method.
-func (m *MockCategoryRepository) GetAllCategories() ([]models.Category, error) {
+func (m *MockCategoryRepository) GetAllCategories(ctx context.Context) ([]models.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCategories")
+	ret := m.ctrl.Call(m, "GetAllCategories", ctx)
 	ret0, _ := ret[0].([]models.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllCategories indicates an expected call of GetAllCategories.
-func (mr *MockCategoryRepositoryMockRecorder) GetAllCategories() *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) GetAllCategories(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockCategoryRepository)(nil).GetAllCategories))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockCategoryRepository)(nil).GetAllCategories), ctx)
+}
+
+// GetAllCategoriesIncludingDeleted mocks base method.
+func (m *MockCategoryRepository) GetAllCategoriesIncludingDeleted(ctx context.Context) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategoriesIncludingDeleted", ctx)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategoriesIncludingDeleted indicates an expected call of GetAllCategoriesIncludingDeleted.
+func (mr *MockCategoryRepositoryMockRecorder) GetAllCategoriesIncludingDeleted(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategoriesIncludingDeleted", reflect.TypeOf((*MockCategoryRepository)(nil).GetAllCategoriesIncludingDeleted), ctx)
 }
 
 // GetCategoryByID mocks base method.
-func (m *MockCategoryRepository) GetCategoryByID(id uint) (models.Category, error) {
+func (m *MockCategoryRepository) GetCategoryByID(ctx context.Context, id uint) (models.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategoryByID", id)
+	ret := m.ctrl.Call(m, "GetCategoryByID", ctx, id)
 	ret0, _ := ret[0].(models.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCategoryByID indicates an expected call of GetCategoryByID.
-func (mr *MockCategoryRepositoryMockRecorder) GetCategoryByID(id interface{}) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoryByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoryByID), ctx, id)
+}
+
+// PurgeDeletedCategories mocks base method.
+func (m *MockCategoryRepository) PurgeDeletedCategories(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedCategories", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedCategories indicates an expected call of PurgeDeletedCategories.
+func (mr *MockCategoryRepositoryMockRecorder) PurgeDeletedCategories(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedCategories", reflect.TypeOf((*MockCategoryRepository)(nil).PurgeDeletedCategories), ctx, deletedBefore)
+}
+
+// ReassignProductsAndDeleteCategory mocks base method.
+func (m *MockCategoryRepository) ReassignProductsAndDeleteCategory(ctx context.Context, id, reassignTo uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignProductsAndDeleteCategory", ctx, id, reassignTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReassignProductsAndDeleteCategory indicates an expected call of ReassignProductsAndDeleteCategory.
+func (mr *MockCategoryRepositoryMockRecorder) ReassignProductsAndDeleteCategory(ctx, id, reassignTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignProductsAndDeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).ReassignProductsAndDeleteCategory), ctx, id, reassignTo)
+}
+
+// RestoreCategory mocks base method.
+func (m *MockCategoryRepository) RestoreCategory(ctx context.Context, id uint) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategory", ctx, id)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCategory indicates an expected call of RestoreCategory.
+func (mr *MockCategoryRepositoryMockRecorder) RestoreCategory(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoryByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategory", reflect.TypeOf((*MockCategoryRepository)(nil).RestoreCategory), ctx, id)
 }
 
 // UpdateCategory mocks base method.
-func (m *MockCategoryRepository) UpdateCategory(category *models.Category) error {
+func (m *MockCategoryRepository) UpdateCategory(ctx context.Context, category *models.Category) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCategory", category)
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCategory indicates an expected call of UpdateCategory.
-func (mr *MockCategoryRepositoryMockRecorder) UpdateCategory(category interface{}) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) UpdateCategory(ctx, category interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryRepository)(nil).UpdateCategory), category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryRepository)(nil).UpdateCategory), ctx, category)
 }
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,73 +36,146 @@ func (m *MockCategoryService) EXPECT() *MockCategoryServiceMockRecorder {
 }
 
 // CreateCategory mocks base method.
-func (m *MockCategoryService) CreateCategory(category *models.Category) error {
+func (m *MockCategoryService) CreateCategory(ctx context.Context, category *models.Category) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCategory", category)
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, category)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateCategory indicates an expected call of CreateCategory.
-func (mr *MockCategoryServiceMockRecorder) CreateCategory(category interface{}) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) CreateCategory(ctx, category interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryService)(nil).CreateCategory), category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryService)(nil).CreateCategory), ctx, category)
+}
+
+// DeactivateProductsAndDeleteCategory mocks base method.
+func (m *MockCategoryService) DeactivateProductsAndDeleteCategory(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateProductsAndDeleteCategory", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateProductsAndDeleteCategory indicates an expected call of DeactivateProductsAndDeleteCategory.
+func (mr *MockCategoryServiceMockRecorder) DeactivateProductsAndDeleteCategory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateProductsAndDeleteCategory", reflect.TypeOf((*MockCategoryService)(nil).DeactivateProductsAndDeleteCategory), ctx, id)
 }
 
 // DeleteCategory mocks base method.
-func (m *MockCategoryService) DeleteCategory(id uint) error {
+func (m *MockCategoryService) DeleteCategory(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", id)
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockCategoryServiceMockRecorder) DeleteCategory(id interface{}) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) DeleteCategory(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryService)(nil).DeleteCategory), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryService)(nil).DeleteCategory), ctx, id)
 }
 
 // GetAllCategories mocks base method.
-func (m *MockCategoryService) GetAllCategories() ([]models.Category, error) {
+func (m *MockCategoryService) GetAllCategories(ctx context.Context) ([]models.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCategories")
+	ret := m.ctrl.Call(m, "GetAllCategories", ctx)
 	ret0, _ := ret[0].([]models.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllCategories indicates an expected call of GetAllCategories.
-func (mr *MockCategoryServiceMockRecorder) GetAllCategories() *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) GetAllCategories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockCategoryService)(nil).GetAllCategories), ctx)
+}
+
+// GetAllCategoriesIncludingDeleted mocks base method.
+func (m *MockCategoryService) GetAllCategoriesIncludingDeleted(ctx context.Context) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategoriesIncludingDeleted", ctx)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategoriesIncludingDeleted indicates an expected call of GetAllCategoriesIncludingDeleted.
+func (mr *MockCategoryServiceMockRecorder) GetAllCategoriesIncludingDeleted(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockCategoryService)(nil).GetAllCategories))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategoriesIncludingDeleted", reflect.TypeOf((*MockCategoryService)(nil).GetAllCategoriesIncludingDeleted), ctx)
 }
 
 // GetCategoryByID mocks base method.
-func (m *MockCategoryService) GetCategoryByID(id uint) (models.Category, error) {
+func (m *MockCategoryService) GetCategoryByID(ctx context.Context, id uint) (models.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategoryByID", id)
+	ret := m.ctrl.Call(m, "GetCategoryByID", ctx, id)
 	ret0, _ := ret[0].(models.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCategoryByID indicates an expected call of GetCategoryByID.
-func (mr *MockCategoryServiceMockRecorder) GetCategoryByID(id interface{}) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) GetCategoryByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockCategoryService)(nil).GetCategoryByID), ctx, id)
+}
+
+// GetCategoryTree mocks base method.
+func (m *MockCategoryService) GetCategoryTree(ctx context.Context) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryTree", ctx)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryTree indicates an expected call of GetCategoryTree.
+func (mr *MockCategoryServiceMockRecorder) GetCategoryTree(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryTree", reflect.TypeOf((*MockCategoryService)(nil).GetCategoryTree), ctx)
+}
+
+// ReassignProductsAndDeleteCategory mocks base method.
+func (m *MockCategoryService) ReassignProductsAndDeleteCategory(ctx context.Context, id, reassignTo uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignProductsAndDeleteCategory", ctx, id, reassignTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReassignProductsAndDeleteCategory indicates an expected call of ReassignProductsAndDeleteCategory.
+func (mr *MockCategoryServiceMockRecorder) ReassignProductsAndDeleteCategory(ctx, id, reassignTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignProductsAndDeleteCategory", reflect.TypeOf((*MockCategoryService)(nil).ReassignProductsAndDeleteCategory), ctx, id, reassignTo)
+}
+
+// RestoreCategory mocks base method.
+func (m *MockCategoryService) RestoreCategory(ctx context.Context, id uint) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategory", ctx, id)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCategory indicates an expected call of RestoreCategory.
+func (mr *MockCategoryServiceMockRecorder) RestoreCategory(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockCategoryService)(nil).GetCategoryByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategory", reflect.TypeOf((*MockCategoryService)(nil).RestoreCategory), ctx, id)
 }
 
 // UpdateCategory mocks base method.
-func (m *MockCategoryService) UpdateCategory(category *models.Category) error {
+func (m *MockCategoryService) UpdateCategory(ctx context.Context, category *models.Category) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCategory", category)
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCategory indicates an expected call of UpdateCategory.
-func (mr *MockCategoryServiceMockRecorder) UpdateCategory(category interface{}) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) UpdateCategory(ctx, category interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryService)(nil).UpdateCategory), category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryService)(nil).UpdateCategory), ctx, category)
 }
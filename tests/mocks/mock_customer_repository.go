@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./cmd/repositories/customer_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/ndkode/elabram-backend-recruitment/cmd/models"
+)
+
+// MockCustomerRepository is a mock of CustomerRepository interface.
+type MockCustomerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerRepositoryMockRecorder
+}
+
+// MockCustomerRepositoryMockRecorder is the mock recorder for MockCustomerRepository.
+type MockCustomerRepositoryMockRecorder struct {
+	mock *MockCustomerRepository
+}
+
+// NewMockCustomerRepository creates a new mock instance.
+func NewMockCustomerRepository(ctrl *gomock.Controller) *MockCustomerRepository {
+	mock := &MockCustomerRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerRepository) EXPECT() *MockCustomerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCustomer mocks base method.
+func (m *MockCustomerRepository) CreateCustomer(ctx context.Context, customer *models.Customer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomer", ctx, customer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCustomer indicates an expected call of CreateCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) CreateCustomer(ctx, customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).CreateCustomer), ctx, customer)
+}
+
+// DeleteCustomer mocks base method.
+func (m *MockCustomerRepository) DeleteCustomer(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomer indicates an expected call of DeleteCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) DeleteCustomer(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).DeleteCustomer), ctx, id)
+}
+
+// GetAllCustomersWithPagination mocks base method.
+func (m *MockCustomerRepository) GetAllCustomersWithPagination(ctx *gin.Context) (models.CustomersPageable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCustomersWithPagination", ctx)
+	ret0, _ := ret[0].(models.CustomersPageable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCustomersWithPagination indicates an expected call of GetAllCustomersWithPagination.
+func (mr *MockCustomerRepositoryMockRecorder) GetAllCustomersWithPagination(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomersWithPagination", reflect.TypeOf((*MockCustomerRepository)(nil).GetAllCustomersWithPagination), ctx)
+}
+
+// GetCustomerByID mocks base method.
+func (m *MockCustomerRepository) GetCustomerByID(ctx context.Context, id uint) (models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerByID", ctx, id)
+	ret0, _ := ret[0].(models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerByID indicates an expected call of GetCustomerByID.
+func (mr *MockCustomerRepositoryMockRecorder) GetCustomerByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerByID", reflect.TypeOf((*MockCustomerRepository)(nil).GetCustomerByID), ctx, id)
+}
+
+// GetCustomerOrdersWithPagination mocks base method.
+func (m *MockCustomerRepository) GetCustomerOrdersWithPagination(ctx *gin.Context, id uint) (models.OrdersPageable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerOrdersWithPagination", ctx, id)
+	ret0, _ := ret[0].(models.OrdersPageable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerOrdersWithPagination indicates an expected call of GetCustomerOrdersWithPagination.
+func (mr *MockCustomerRepositoryMockRecorder) GetCustomerOrdersWithPagination(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerOrdersWithPagination", reflect.TypeOf((*MockCustomerRepository)(nil).GetCustomerOrdersWithPagination), ctx, id)
+}
+
+// IsEmailTaken mocks base method.
+func (m *MockCustomerRepository) IsEmailTaken(ctx context.Context, email string, excludeID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEmailTaken", ctx, email, excludeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEmailTaken indicates an expected call of IsEmailTaken.
+func (mr *MockCustomerRepositoryMockRecorder) IsEmailTaken(ctx, email, excludeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmailTaken", reflect.TypeOf((*MockCustomerRepository)(nil).IsEmailTaken), ctx, email, excludeID)
+}
+
+// UpdateCustomer mocks base method.
+func (m *MockCustomerRepository) UpdateCustomer(ctx context.Context, customer *models.Customer) (models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomer", ctx, customer)
+	ret0, _ := ret[0].(models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomer indicates an expected call of UpdateCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) UpdateCustomer(ctx, customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).UpdateCustomer), ctx, customer)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./cmd/services/customer_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/ndkode/elabram-backend-recruitment/cmd/models"
+)
+
+// MockCustomerService is a mock of CustomerService interface.
+type MockCustomerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerServiceMockRecorder
+}
+
+// MockCustomerServiceMockRecorder is the mock recorder for MockCustomerService.
+type MockCustomerServiceMockRecorder struct {
+	mock *MockCustomerService
+}
+
+// NewMockCustomerService creates a new mock instance.
+func NewMockCustomerService(ctrl *gomock.Controller) *MockCustomerService {
+	mock := &MockCustomerService{ctrl: ctrl}
+	mock.recorder = &MockCustomerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerService) EXPECT() *MockCustomerServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCustomer mocks base method.
+func (m *MockCustomerService) CreateCustomer(ctx context.Context, customer *models.Customer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomer", ctx, customer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCustomer indicates an expected call of CreateCustomer.
+func (mr *MockCustomerServiceMockRecorder) CreateCustomer(ctx, customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomer", reflect.TypeOf((*MockCustomerService)(nil).CreateCustomer), ctx, customer)
+}
+
+// DeleteCustomer mocks base method.
+func (m *MockCustomerService) DeleteCustomer(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomer indicates an expected call of DeleteCustomer.
+func (mr *MockCustomerServiceMockRecorder) DeleteCustomer(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomer", reflect.TypeOf((*MockCustomerService)(nil).DeleteCustomer), ctx, id)
+}
+
+// GetAllCustomersWithPagination mocks base method.
+func (m *MockCustomerService) GetAllCustomersWithPagination(ctx *gin.Context) (models.CustomersPageable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCustomersWithPagination", ctx)
+	ret0, _ := ret[0].(models.CustomersPageable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCustomersWithPagination indicates an expected call of GetAllCustomersWithPagination.
+func (mr *MockCustomerServiceMockRecorder) GetAllCustomersWithPagination(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomersWithPagination", reflect.TypeOf((*MockCustomerService)(nil).GetAllCustomersWithPagination), ctx)
+}
+
+// GetCustomerByID mocks base method.
+func (m *MockCustomerService) GetCustomerByID(ctx context.Context, id uint) (models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerByID", ctx, id)
+	ret0, _ := ret[0].(models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerByID indicates an expected call of GetCustomerByID.
+func (mr *MockCustomerServiceMockRecorder) GetCustomerByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerByID", reflect.TypeOf((*MockCustomerService)(nil).GetCustomerByID), ctx, id)
+}
+
+// GetCustomerOrdersWithPagination mocks base method.
+func (m *MockCustomerService) GetCustomerOrdersWithPagination(ctx *gin.Context, id uint) (models.OrdersPageable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerOrdersWithPagination", ctx, id)
+	ret0, _ := ret[0].(models.OrdersPageable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerOrdersWithPagination indicates an expected call of GetCustomerOrdersWithPagination.
+func (mr *MockCustomerServiceMockRecorder) GetCustomerOrdersWithPagination(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerOrdersWithPagination", reflect.TypeOf((*MockCustomerService)(nil).GetCustomerOrdersWithPagination), ctx, id)
+}
+
+// IsEmailTaken mocks base method.
+func (m *MockCustomerService) IsEmailTaken(ctx context.Context, email string, excludeID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEmailTaken", ctx, email, excludeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEmailTaken indicates an expected call of IsEmailTaken.
+func (mr *MockCustomerServiceMockRecorder) IsEmailTaken(ctx, email, excludeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmailTaken", reflect.TypeOf((*MockCustomerService)(nil).IsEmailTaken), ctx, email, excludeID)
+}
+
+// UpdateCustomer mocks base method.
+func (m *MockCustomerService) UpdateCustomer(ctx context.Context, customer *models.Customer) (models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomer", ctx, customer)
+	ret0, _ := ret[0].(models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomer indicates an expected call of UpdateCustomer.
+func (mr *MockCustomerServiceMockRecorder) UpdateCustomer(ctx, customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomer", reflect.TypeOf((*MockCustomerService)(nil).UpdateCustomer), ctx, customer)
+}
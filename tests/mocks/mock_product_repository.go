@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gin "github.com/gin-gonic/gin"
 	gomock "github.com/golang/mock/gomock"
@@ -36,46 +38,46 @@ func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
 }
 
 // CreateProduct mocks base method.
-func (m *MockProductRepository) CreateProduct(product *models.Product) error {
+func (m *MockProductRepository) CreateProduct(ctx context.Context, product *models.Product) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProduct", product)
+	ret := m.ctrl.Call(m, "CreateProduct", ctx, product)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateProduct indicates an expected call of CreateProduct.
-func (mr *MockProductRepositoryMockRecorder) CreateProduct(product interface{}) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) CreateProduct(ctx, product interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockProductRepository)(nil).CreateProduct), product)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockProductRepository)(nil).CreateProduct), ctx, product)
 }
 
 // DeleteProduct mocks base method.
-func (m *MockProductRepository) DeleteProduct(id uint) error {
+func (m *MockProductRepository) DeleteProduct(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", id)
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockProductRepositoryMockRecorder) DeleteProduct(id interface{}) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) DeleteProduct(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductRepository)(nil).DeleteProduct), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductRepository)(nil).DeleteProduct), ctx, id)
 }
 
 // GetAllProducts mocks base method.
-func (m *MockProductRepository) GetAllProducts() ([]models.Product, error) {
+func (m *MockProductRepository) GetAllProducts(ctx context.Context) ([]models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProducts")
+	ret := m.ctrl.Call(m, "GetAllProducts", ctx)
 	ret0, _ := ret[0].([]models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllProducts indicates an expected call of GetAllProducts.
-func (mr *MockProductRepositoryMockRecorder) GetAllProducts() *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) GetAllProducts(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockProductRepository)(nil).GetAllProducts))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockProductRepository)(nil).GetAllProducts), ctx)
 }
 
 // GetAllProductsWithPagination mocks base method.
@@ -94,31 +96,61 @@ func (mr *MockProductRepositoryMockRecorder) GetAllProductsWithPagination(ctx in
 }
 
 // GetProductByID mocks base method.
-func (m *MockProductRepository) GetProductByID(id uint) (models.Product, error) {
+func (m *MockProductRepository) GetProductByID(ctx context.Context, id uint) (models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductByID", id)
+	ret := m.ctrl.Call(m, "GetProductByID", ctx, id)
 	ret0, _ := ret[0].(models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProductByID indicates an expected call of GetProductByID.
-func (mr *MockProductRepositoryMockRecorder) GetProductByID(id interface{}) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) GetProductByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockProductRepository)(nil).GetProductByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockProductRepository)(nil).GetProductByID), ctx, id)
+}
+
+// PurgeDeletedProducts mocks base method.
+func (m *MockProductRepository) PurgeDeletedProducts(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedProducts", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedProducts indicates an expected call of PurgeDeletedProducts.
+func (mr *MockProductRepositoryMockRecorder) PurgeDeletedProducts(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedProducts", reflect.TypeOf((*MockProductRepository)(nil).PurgeDeletedProducts), ctx, deletedBefore)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockProductRepository) RestoreProduct(ctx context.Context, id uint) (models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", ctx, id)
+	ret0, _ := ret[0].(models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockProductRepositoryMockRecorder) RestoreProduct(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockProductRepository)(nil).RestoreProduct), ctx, id)
 }
 
 // UpdateProduct mocks base method.
-func (m *MockProductRepository) UpdateProduct(product *models.Product) (models.Product, error) {
+func (m *MockProductRepository) UpdateProduct(ctx context.Context, product *models.Product) (models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProduct", product)
+	ret := m.ctrl.Call(m, "UpdateProduct", ctx, product)
 	ret0, _ := ret[0].(models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateProduct indicates an expected call of UpdateProduct.
-func (mr *MockProductRepositoryMockRecorder) UpdateProduct(product interface{}) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) UpdateProduct(ctx, product interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockProductRepository)(nil).UpdateProduct), product)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockProductRepository)(nil).UpdateProduct), ctx, product)
 }
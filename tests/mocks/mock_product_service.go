@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gin "github.com/gin-gonic/gin"
@@ -36,46 +37,46 @@ func (m *MockProductService) EXPECT() *MockProductServiceMockRecorder {
 }
 
 // CreateProduct mocks base method.
-func (m *MockProductService) CreateProduct(product *models.Product) error {
+func (m *MockProductService) CreateProduct(ctx context.Context, product *models.Product) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProduct", product)
+	ret := m.ctrl.Call(m, "CreateProduct", ctx, product)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateProduct indicates an expected call of CreateProduct.
-func (mr *MockProductServiceMockRecorder) CreateProduct(product interface{}) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) CreateProduct(ctx, product interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockProductService)(nil).CreateProduct), product)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockProductService)(nil).CreateProduct), ctx, product)
 }
 
 // DeleteProduct mocks base method.
-func (m *MockProductService) DeleteProduct(id uint) error {
+func (m *MockProductService) DeleteProduct(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", id)
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockProductServiceMockRecorder) DeleteProduct(id interface{}) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) DeleteProduct(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductService)(nil).DeleteProduct), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductService)(nil).DeleteProduct), ctx, id)
 }
 
 // GetAllProducts mocks base method.
-func (m *MockProductService) GetAllProducts() ([]models.Product, error) {
+func (m *MockProductService) GetAllProducts(ctx context.Context) ([]models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProducts")
+	ret := m.ctrl.Call(m, "GetAllProducts", ctx)
 	ret0, _ := ret[0].([]models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllProducts indicates an expected call of GetAllProducts.
-func (mr *MockProductServiceMockRecorder) GetAllProducts() *gomock.Call {
+func (mr *MockProductServiceMockRecorder) GetAllProducts(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockProductService)(nil).GetAllProducts))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockProductService)(nil).GetAllProducts), ctx)
 }
 
 // GetAllProductsWithPagination mocks base method.
@@ -94,31 +95,46 @@ func (mr *MockProductServiceMockRecorder) GetAllProductsWithPagination(ctx inter
 }
 
 // GetProductByID mocks base method.
-func (m *MockProductService) GetProductByID(id uint) (models.Product, error) {
+func (m *MockProductService) GetProductByID(ctx context.Context, id uint) (models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductByID", id)
+	ret := m.ctrl.Call(m, "GetProductByID", ctx, id)
 	ret0, _ := ret[0].(models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProductByID indicates an expected call of GetProductByID.
-func (mr *MockProductServiceMockRecorder) GetProductByID(id interface{}) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) GetProductByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockProductService)(nil).GetProductByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockProductService)(nil).GetProductByID), ctx, id)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockProductService) RestoreProduct(ctx context.Context, id uint) (models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", ctx, id)
+	ret0, _ := ret[0].(models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockProductServiceMockRecorder) RestoreProduct(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockProductService)(nil).RestoreProduct), ctx, id)
 }
 
 // UpdateProduct mocks base method.
-func (m *MockProductService) UpdateProduct(product *models.Product) (models.Product, error) {
+func (m *MockProductService) UpdateProduct(ctx context.Context, product *models.Product) (models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProduct", product)
+	ret := m.ctrl.Call(m, "UpdateProduct", ctx, product)
 	ret0, _ := ret[0].(models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateProduct indicates an expected call of UpdateProduct.
-func (mr *MockProductServiceMockRecorder) UpdateProduct(product interface{}) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) UpdateProduct(ctx, product interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockProductService)(nil).UpdateProduct), product)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockProductService)(nil).UpdateProduct), ctx, product)
 }
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./cmd/repositories/report_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/ndkode/elabram-backend-recruitment/cmd/models"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExportProductReport mocks base method.
+func (m *MockReportRepository) ExportProductReport(ctx context.Context, query models.ReportQuery, write func(models.ProductExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProductReport", ctx, query, write)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProductReport indicates an expected call of ExportProductReport.
+func (mr *MockReportRepositoryMockRecorder) ExportProductReport(ctx, query, write interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProductReport", reflect.TypeOf((*MockReportRepository)(nil).ExportProductReport), ctx, query, write)
+}
+
+// GenerateProductReport mocks base method.
+func (m *MockReportRepository) GenerateProductReport(ctx context.Context, query models.ReportQuery) (models.ProductReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateProductReport", ctx, query)
+	ret0, _ := ret[0].(models.ProductReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateProductReport indicates an expected call of GenerateProductReport.
+func (mr *MockReportRepositoryMockRecorder) GenerateProductReport(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateProductReport", reflect.TypeOf((*MockReportRepository)(nil).GenerateProductReport), ctx, query)
+}
+
+// GenerateProductReportSingleQuery mocks base method.
+func (m *MockReportRepository) GenerateProductReportSingleQuery(ctx context.Context, query models.ReportQuery) (models.ProductReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateProductReportSingleQuery", ctx, query)
+	ret0, _ := ret[0].(models.ProductReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateProductReportSingleQuery indicates an expected call of GenerateProductReportSingleQuery.
+func (mr *MockReportRepositoryMockRecorder) GenerateProductReportSingleQuery(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateProductReportSingleQuery", reflect.TypeOf((*MockReportRepository)(nil).GenerateProductReportSingleQuery), ctx, query)
+}
+
+// GenerateProductReportWithGoroutines mocks base method.
+func (m *MockReportRepository) GenerateProductReportWithGoroutines(ctx context.Context, query models.ReportQuery) (models.ProductReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateProductReportWithGoroutines", ctx, query)
+	ret0, _ := ret[0].(models.ProductReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateProductReportWithGoroutines indicates an expected call of GenerateProductReportWithGoroutines.
+func (mr *MockReportRepositoryMockRecorder) GenerateProductReportWithGoroutines(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateProductReportWithGoroutines", reflect.TypeOf((*MockReportRepository)(nil).GenerateProductReportWithGoroutines), ctx, query)
+}
+
+// GenerateProductSalesReport mocks base method.
+func (m *MockReportRepository) GenerateProductSalesReport(ctx context.Context, query models.ProductSalesQuery) (models.ProductSalesPageable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateProductSalesReport", ctx, query)
+	ret0, _ := ret[0].(models.ProductSalesPageable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateProductSalesReport indicates an expected call of GenerateProductSalesReport.
+func (mr *MockReportRepositoryMockRecorder) GenerateProductSalesReport(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateProductSalesReport", reflect.TypeOf((*MockReportRepository)(nil).GenerateProductSalesReport), ctx, query)
+}
+
+// GenerateSalesTimeseriesReport mocks base method.
+func (m *MockReportRepository) GenerateSalesTimeseriesReport(ctx context.Context, query models.SalesTimeseriesQuery) (models.SalesTimeseriesReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSalesTimeseriesReport", ctx, query)
+	ret0, _ := ret[0].(models.SalesTimeseriesReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSalesTimeseriesReport indicates an expected call of GenerateSalesTimeseriesReport.
+func (mr *MockReportRepositoryMockRecorder) GenerateSalesTimeseriesReport(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSalesTimeseriesReport", reflect.TypeOf((*MockReportRepository)(nil).GenerateSalesTimeseriesReport), ctx, query)
+}
+
+// GenerateTopCustomersReport mocks base method.
+func (m *MockReportRepository) GenerateTopCustomersReport(ctx context.Context, query models.TopCustomersQuery) ([]models.TopCustomer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTopCustomersReport", ctx, query)
+	ret0, _ := ret[0].([]models.TopCustomer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateTopCustomersReport indicates an expected call of GenerateTopCustomersReport.
+func (mr *MockReportRepositoryMockRecorder) GenerateTopCustomersReport(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTopCustomersReport", reflect.TypeOf((*MockReportRepository)(nil).GenerateTopCustomersReport), ctx, query)
+}
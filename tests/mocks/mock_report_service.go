@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./cmd/services/report_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/ndkode/elabram-backend-recruitment/cmd/models"
+)
+
+// MockReportService is a mock of ReportService interface.
+type MockReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportServiceMockRecorder
+}
+
+// MockReportServiceMockRecorder is the mock recorder for MockReportService.
+type MockReportServiceMockRecorder struct {
+	mock *MockReportService
+}
+
+// NewMockReportService creates a new mock instance.
+func NewMockReportService(ctrl *gomock.Controller) *MockReportService {
+	mock := &MockReportService{ctrl: ctrl}
+	mock.recorder = &MockReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportService) EXPECT() *MockReportServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportProductReport mocks base method.
+func (m *MockReportService) ExportProductReport(ctx *gin.Context, query models.ReportQuery, write func(models.ProductExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProductReport", ctx, query, write)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProductReport indicates an expected call of ExportProductReport.
+func (mr *MockReportServiceMockRecorder) ExportProductReport(ctx, query, write interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProductReport", reflect.TypeOf((*MockReportService)(nil).ExportProductReport), ctx, query, write)
+}
+
+// GenerateProductReport mocks base method.
+func (m *MockReportService) GenerateProductReport(ctx *gin.Context, query models.ReportQuery) (models.ProductReport, models.ReportCacheStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateProductReport", ctx, query)
+	ret0, _ := ret[0].(models.ProductReport)
+	ret1, _ := ret[1].(models.ReportCacheStatus)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateProductReport indicates an expected call of GenerateProductReport.
+func (mr *MockReportServiceMockRecorder) GenerateProductReport(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateProductReport", reflect.TypeOf((*MockReportService)(nil).GenerateProductReport), ctx, query)
+}
+
+// GenerateProductSalesReport mocks base method.
+func (m *MockReportService) GenerateProductSalesReport(ctx *gin.Context, query models.ProductSalesQuery) (models.ProductSalesPageable, models.ReportCacheStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateProductSalesReport", ctx, query)
+	ret0, _ := ret[0].(models.ProductSalesPageable)
+	ret1, _ := ret[1].(models.ReportCacheStatus)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateProductSalesReport indicates an expected call of GenerateProductSalesReport.
+func (mr *MockReportServiceMockRecorder) GenerateProductSalesReport(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateProductSalesReport", reflect.TypeOf((*MockReportService)(nil).GenerateProductSalesReport), ctx, query)
+}
+
+// GenerateSalesTimeseriesReport mocks base method.
+func (m *MockReportService) GenerateSalesTimeseriesReport(ctx *gin.Context, query models.SalesTimeseriesQuery) (models.SalesTimeseriesReport, models.ReportCacheStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSalesTimeseriesReport", ctx, query)
+	ret0, _ := ret[0].(models.SalesTimeseriesReport)
+	ret1, _ := ret[1].(models.ReportCacheStatus)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateSalesTimeseriesReport indicates an expected call of GenerateSalesTimeseriesReport.
+func (mr *MockReportServiceMockRecorder) GenerateSalesTimeseriesReport(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSalesTimeseriesReport", reflect.TypeOf((*MockReportService)(nil).GenerateSalesTimeseriesReport), ctx, query)
+}
+
+// GenerateTopCustomersReport mocks base method.
+func (m *MockReportService) GenerateTopCustomersReport(ctx *gin.Context, query models.TopCustomersQuery) ([]models.TopCustomer, models.ReportCacheStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTopCustomersReport", ctx, query)
+	ret0, _ := ret[0].([]models.TopCustomer)
+	ret1, _ := ret[1].(models.ReportCacheStatus)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateTopCustomersReport indicates an expected call of GenerateTopCustomersReport.
+func (mr *MockReportServiceMockRecorder) GenerateTopCustomersReport(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTopCustomersReport", reflect.TypeOf((*MockReportService)(nil).GenerateTopCustomersReport), ctx, query)
+}
@@ -0,0 +1,68 @@
+package ratelimit_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimiterAllow(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	limit := ratelimit.Limit{Requests: 2, Window: time.Minute}
+
+	first, _ := limiter.Allow(context.Background(), "client", limit)
+	second, _ := limiter.Allow(context.Background(), "client", limit)
+	third, _ := limiter.Allow(context.Background(), "client", limit)
+
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 1, first.Remaining)
+	assert.True(t, second.Allowed)
+	assert.Equal(t, 0, second.Remaining)
+	assert.False(t, third.Allowed)
+	assert.Greater(t, third.ResetAfter, 59*time.Second)
+}
+
+func TestMemoryLimiterKeysAreIndependent(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	limit := ratelimit.Limit{Requests: 1, Window: time.Minute}
+
+	first, _ := limiter.Allow(context.Background(), "client-1", limit)
+	second, _ := limiter.Allow(context.Background(), "client-2", limit)
+
+	assert.True(t, first.Allowed)
+	assert.True(t, second.Allowed)
+}
+
+func TestMemoryLimiterSlidingWindow(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	limit := ratelimit.Limit{Requests: 1, Window: 50 * time.Millisecond}
+
+	first, _ := limiter.Allow(context.Background(), "client", limit)
+	blocked, _ := limiter.Allow(context.Background(), "client", limit)
+	time.Sleep(60 * time.Millisecond)
+	afterWindow, _ := limiter.Allow(context.Background(), "client", limit)
+
+	assert.True(t, first.Allowed)
+	assert.False(t, blocked.Allowed)
+	assert.True(t, afterWindow.Allowed)
+}
+
+func TestMemoryLimiterSweepsQuietKeys(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	limit := ratelimit.Limit{Requests: 1, Window: 20 * time.Millisecond}
+
+	// Clients that never come back are dropped once their window is over
+	for i := 0; i < 1000; i++ {
+		limiter.Allow(context.Background(), "gone-"+strconv.Itoa(i), limit)
+	}
+	time.Sleep(30 * time.Millisecond)
+	for i := 0; i < 100; i++ {
+		limiter.Allow(context.Background(), "client-"+strconv.Itoa(i), limit)
+	}
+
+	assert.Less(t, limiter.Len(), 1000)
+}
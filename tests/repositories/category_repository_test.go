@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -40,26 +41,202 @@ func TestCategoryRepository(t *testing.T) {
 
 	// Test Create
 	category := models.Category{Name: "Test Category", Description: "Test Category Description"}
-	err = repo.CreateCategory(&category)
+	err = repo.CreateCategory(context.Background(), &category)
 	assert.NoError(t, err)
 
 	// Test GetAll
-	categories, err := repo.GetAllCategories()
+	categories, err := repo.GetAllCategories(context.Background())
 	assert.NoError(t, err)
 	assert.GreaterOrEqual(t, len(categories), 0)
 	assert.Equal(t, category.Name, categories[len(categories)-1].Name)
 
 	// Test GetByID
-	categoryByID, err := repo.GetCategoryByID(categories[len(categories)-1].ID)
+	categoryByID, err := repo.GetCategoryByID(context.Background(), categories[len(categories)-1].ID)
 	assert.NoError(t, err)
 	assert.Equal(t, category.Name, categoryByID.Name)
 
 	// Test Update
 	category.Name = "Updated Test Category"
-	err = repo.UpdateCategory(&category)
+	err = repo.UpdateCategory(context.Background(), &category)
 	assert.NoError(t, err)
 
 	// Test Delete
-	err = repo.DeleteCategory(categories[len(categories)-1].ID)
+	err = repo.DeleteCategory(context.Background(), categories[len(categories)-1].ID)
 	assert.NoError(t, err)
 }
+
+func TestCategoryRepositoryDeleteWithProducts(t *testing.T) {
+	// Load the database configuration
+	os.Setenv("DB_USERNAME", "root")
+	os.Setenv("DB_PASSWORD", "rootpassword")
+	os.Setenv("DB_HOST", "127.0.0.1")
+	os.Setenv("DB_PORT", "3306")
+	os.Setenv("DB_NAME", "elabram")
+	// Connect to the database
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		os.Getenv("DB_USERNAME"),
+		os.Getenv("DB_PASSWORD"),
+		os.Getenv("DB_HOST"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_NAME"),
+	)
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("could not connect to database: %v", err)
+	}
+
+	// Migrate the schema
+	db.AutoMigrate(&models.Category{}, &models.Product{})
+
+	// Create a new repository
+	repo := repositories.NewCategoryRepository(db)
+
+	// Create two categories and a product in the first one
+	category := models.Category{Name: "Old Category"}
+	err = repo.CreateCategory(context.Background(), &category)
+	assert.NoError(t, err)
+	target := models.Category{Name: "New Category"}
+	err = repo.CreateCategory(context.Background(), &target)
+	assert.NoError(t, err)
+	product := models.Product{Name: "Test Product", Price: 100, StockQuantity: 1, CategoryID: category.ID, IsActive: true}
+	err = repositories.NewProductRepository(db).CreateProduct(context.Background(), &product)
+	assert.NoError(t, err)
+
+	// Test Delete refuses while products reference the category
+	err = repo.DeleteCategory(context.Background(), category.ID)
+	assert.ErrorIs(t, err, models.ErrCategoryInUse)
+
+	// Test Delete reassigning the products
+	err = repo.ReassignProductsAndDeleteCategory(context.Background(), category.ID, target.ID)
+	assert.NoError(t, err)
+	reassignedProduct, err := repositories.NewProductRepository(db).GetProductByID(context.Background(), product.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, target.ID, reassignedProduct.CategoryID)
+
+	// Test Delete deactivating the products
+	err = repo.DeactivateProductsAndDeleteCategory(context.Background(), target.ID)
+	assert.NoError(t, err)
+	deactivatedProduct, err := repositories.NewProductRepository(db).GetProductByID(context.Background(), product.ID)
+	assert.NoError(t, err)
+	assert.False(t, deactivatedProduct.IsActive)
+}
+
+func TestCategoryRepositoryReassignDeletedProducts(t *testing.T) {
+	// Load the database configuration
+	os.Setenv("DB_USERNAME", "root")
+	os.Setenv("DB_PASSWORD", "rootpassword")
+	os.Setenv("DB_HOST", "127.0.0.1")
+	os.Setenv("DB_PORT", "3306")
+	os.Setenv("DB_NAME", "elabram")
+	// Connect to the database
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		os.Getenv("DB_USERNAME"),
+		os.Getenv("DB_PASSWORD"),
+		os.Getenv("DB_HOST"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_NAME"),
+	)
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("could not connect to database: %v", err)
+	}
+
+	// Migrate the schema
+	db.AutoMigrate(&models.Category{}, &models.Product{})
+
+	// Create a new repository
+	repo := repositories.NewCategoryRepository(db)
+	productRepo := repositories.NewProductRepository(db)
+
+	// Create two categories and a soft deleted product in the first one
+	category := models.Category{Name: "Old Category"}
+	err = repo.CreateCategory(context.Background(), &category)
+	assert.NoError(t, err)
+	target := models.Category{Name: "New Category"}
+	err = repo.CreateCategory(context.Background(), &target)
+	assert.NoError(t, err)
+	product := models.Product{Name: "Deleted Product", Price: 100, StockQuantity: 1, CategoryID: category.ID, IsActive: true}
+	err = productRepo.CreateProduct(context.Background(), &product)
+	assert.NoError(t, err)
+	err = productRepo.DeleteProduct(context.Background(), product.ID)
+	assert.NoError(t, err)
+
+	// Test Delete refuses while deleted products reference the category
+	err = repo.DeleteCategory(context.Background(), category.ID)
+	assert.ErrorIs(t, err, models.ErrCategoryInUse)
+
+	// Test the restored product belongs to the category it was reassigned to
+	err = repo.ReassignProductsAndDeleteCategory(context.Background(), category.ID, target.ID)
+	assert.NoError(t, err)
+	restoredProduct, err := productRepo.RestoreProduct(context.Background(), product.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, target.ID, restoredProduct.CategoryID)
+
+	// Test Delete deactivating the products deactivates the deleted ones too
+	err = productRepo.DeleteProduct(context.Background(), product.ID)
+	assert.NoError(t, err)
+	err = repo.DeactivateProductsAndDeleteCategory(context.Background(), target.ID)
+	assert.NoError(t, err)
+	restoredProduct, err = productRepo.RestoreProduct(context.Background(), product.ID)
+	assert.NoError(t, err)
+	assert.False(t, restoredProduct.IsActive)
+}
+
+func TestCategoryRepositoryDeletedSubcategories(t *testing.T) {
+	// Load the database configuration
+	os.Setenv("DB_USERNAME", "root")
+	os.Setenv("DB_PASSWORD", "rootpassword")
+	os.Setenv("DB_HOST", "127.0.0.1")
+	os.Setenv("DB_PORT", "3306")
+	os.Setenv("DB_NAME", "elabram")
+	// Connect to the database
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		os.Getenv("DB_USERNAME"),
+		os.Getenv("DB_PASSWORD"),
+		os.Getenv("DB_HOST"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_NAME"),
+	)
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("could not connect to database: %v", err)
+	}
+
+	// Migrate the schema
+	db.AutoMigrate(&models.Category{}, &models.Product{})
+
+	// Create a new repository
+	repo := repositories.NewCategoryRepository(db)
+
+	// Create a grandparent, a parent and a soft deleted child
+	grandparent := models.Category{Name: "Grandparent"}
+	err = repo.CreateCategory(context.Background(), &grandparent)
+	assert.NoError(t, err)
+	parent := models.Category{Name: "Parent", ParentID: &grandparent.ID}
+	err = repo.CreateCategory(context.Background(), &parent)
+	assert.NoError(t, err)
+	child := models.Category{Name: "Child", ParentID: &parent.ID}
+	err = repo.CreateCategory(context.Background(), &child)
+	assert.NoError(t, err)
+	err = repo.DeleteCategory(context.Background(), child.ID)
+	assert.NoError(t, err)
+
+	// Test Delete refuses while deleted subcategories reference the category
+	err = repo.DeleteCategory(context.Background(), parent.ID)
+	assert.ErrorIs(t, err, models.ErrCategoryHasChildren)
+
+	// Test the restored child is moved up to the parent of the deleted category
+	err = repo.DeactivateProductsAndDeleteCategory(context.Background(), parent.ID)
+	assert.NoError(t, err)
+	restoredChild, err := repo.RestoreCategory(context.Background(), child.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, grandparent.ID, *restoredChild.ParentID)
+
+	// Test Restore refuses while the parent is still deleted
+	err = repo.DeleteCategory(context.Background(), child.ID)
+	assert.NoError(t, err)
+	err = db.Delete(&models.Category{}, grandparent.ID).Error
+	assert.NoError(t, err)
+	_, err = repo.RestoreCategory(context.Background(), child.ID)
+	assert.ErrorIs(t, err, models.ErrCategoryParentGone)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestCustomerRepository(t *testing.T) {
+	// Load the database configuration
+	os.Setenv("DB_USERNAME", "root")
+	os.Setenv("DB_PASSWORD", "rootpassword")
+	os.Setenv("DB_HOST", "127.0.0.1")
+	os.Setenv("DB_PORT", "3306")
+	os.Setenv("DB_NAME", "elabram")
+	// Connect to the database
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		os.Getenv("DB_USERNAME"),
+		os.Getenv("DB_PASSWORD"),
+		os.Getenv("DB_HOST"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_NAME"),
+	)
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("could not connect to database: %v", err)
+	}
+
+	// Migrate the schema
+	db.AutoMigrate(&models.Customer{}, &models.Order{})
+
+	// Create a new repository
+	repo := repositories.NewCustomerRepository(db)
+
+	// Test Create
+	customer := models.Customer{Name: "Test Customer", Email: "test.customer@example.com"}
+	err = repo.CreateCustomer(context.Background(), &customer)
+	assert.NoError(t, err)
+
+	// Test IsEmailTaken
+	taken, err := repo.IsEmailTaken(context.Background(), customer.Email, 0)
+	assert.NoError(t, err)
+	assert.True(t, taken)
+	taken, err = repo.IsEmailTaken(context.Background(), customer.Email, customer.ID)
+	assert.NoError(t, err)
+	assert.False(t, taken)
+
+	// Test GetAllWithPagination
+	customersPageable, err := repo.GetAllCustomersWithPagination(&gin.Context{})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, int(customersPageable.TotalItems), 1)
+
+	// Test GetByID
+	customerByID, err := repo.GetCustomerByID(context.Background(), customer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, customer.Email, customerByID.Email)
+
+	// Test Update
+	customer.Name = "Updated Test Customer"
+	result, err := repo.UpdateCustomer(context.Background(), &customer)
+	assert.NoError(t, err)
+	assert.Equal(t, customer.Name, result.Name)
+
+	// Test GetCustomerOrdersWithPagination
+	ordersPageable, err := repo.GetCustomerOrdersWithPagination(&gin.Context{}, customer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), ordersPageable.TotalItems)
+
+	// Test Delete
+	err = repo.DeleteCustomer(context.Background(), customer.ID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestOrderRepository(t *testing.T) {
+	// Load the database configuration
+	os.Setenv("DB_USERNAME", "root")
+	os.Setenv("DB_PASSWORD", "rootpassword")
+	os.Setenv("DB_HOST", "127.0.0.1")
+	os.Setenv("DB_PORT", "3306")
+	os.Setenv("DB_NAME", "elabram")
+	// Connect to the database
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		os.Getenv("DB_USERNAME"),
+		os.Getenv("DB_PASSWORD"),
+		os.Getenv("DB_HOST"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_NAME"),
+	)
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("could not connect to database: %v", err)
+	}
+
+	// Migrate the schema
+	db.AutoMigrate(&models.Customer{}, &models.Product{}, &models.Order{}, &models.OrderItem{})
+
+	// Create a customer placing the orders
+	customer := models.Customer{Name: "Test Order Customer", Email: fmt.Sprintf("test.order.customer+%d@example.com", time.Now().UnixNano())}
+	err = repositories.NewCustomerRepository(db).CreateCustomer(context.Background(), &customer)
+	assert.NoError(t, err)
+
+	// Create a product to order
+	product := models.Product{
+		Name:          "Test Order Product",
+		Description:   "Test Order Product Description",
+		Price:         250,
+		StockQuantity: 10,
+		CategoryID:    1,
+		IsActive:      true,
+	}
+	err = repositories.NewProductRepository(db).CreateProduct(context.Background(), &product)
+	assert.NoError(t, err)
+
+	// Create a new repository
+	repo := repositories.NewOrderRepository(db)
+
+	// Test Create
+	order := models.Order{
+		CustomerID: customer.ID,
+		Items: []models.OrderItem{
+			{ProductID: product.ID, Quantity: 2},
+		},
+	}
+	err = repo.CreateOrder(context.Background(), &order)
+	assert.NoError(t, err)
+	assert.Equal(t, product.Price, order.Items[0].UnitPrice)
+	assert.Equal(t, product.Price*2, order.TotalPrice)
+
+	// Test stock is decremented
+	productAfterOrder, err := repositories.NewProductRepository(db).GetProductByID(context.Background(), product.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, productAfterOrder.StockQuantity)
+
+	// Test Create with insufficient stock rejects the whole order
+	err = repo.CreateOrder(context.Background(), &models.Order{
+		CustomerID: customer.ID,
+		Items: []models.OrderItem{
+			{ProductID: product.ID, Quantity: 1},
+			{ProductID: product.ID, Quantity: 8},
+		},
+	})
+	var stockErr *models.InsufficientStockError
+	assert.ErrorAs(t, err, &stockErr)
+	assert.Equal(t, 9, stockErr.Shortages[0].Requested)
+	assert.Equal(t, 8, stockErr.Shortages[0].Available)
+
+	// Test GetAllWithPagination
+	ordersPageable, err := repo.GetAllOrdersWithPagination(&gin.Context{})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, int(ordersPageable.TotalItems), 1)
+
+	// Test GetByID
+	orderByID, err := repo.GetOrderByID(context.Background(), order.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, order.TotalPrice, orderByID.TotalPrice)
+	assert.Len(t, orderByID.Items, 1)
+}
@@ -1,9 +1,11 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
@@ -48,11 +50,11 @@ func TestProductRepository(t *testing.T) {
 		CategoryID:    1,
 		IsActive:      true,
 	}
-	err = repo.CreateProduct(&product)
+	err = repo.CreateProduct(context.Background(), &product)
 	assert.NoError(t, err)
 
 	// Test GetAll
-	products, err := repo.GetAllProducts()
+	products, err := repo.GetAllProducts(context.Background())
 	assert.NoError(t, err)
 	assert.GreaterOrEqual(t, len(products), 0)
 	assert.Equal(t, product.Name, products[len(products)-1].Name)
@@ -63,17 +65,32 @@ func TestProductRepository(t *testing.T) {
 	assert.GreaterOrEqual(t, int(productsPageable.TotalItems), 0)
 
 	// Test GetByID
-	productByID, err := repo.GetProductByID(products[len(products)-1].ID)
+	productByID, err := repo.GetProductByID(context.Background(), products[len(products)-1].ID)
 	assert.NoError(t, err)
 	assert.Equal(t, product.Name, productByID.Name)
 
 	// Test Update
 	product.Name = "Updated Test Product"
-	result, err := repo.UpdateProduct(&product)
+	result, err := repo.UpdateProduct(context.Background(), &product)
 	assert.NoError(t, err)
 	assert.Equal(t, product.Name, result.Name)
 
 	// Test Delete
-	err = repo.DeleteProduct(products[len(products)-1].ID)
+	err = repo.DeleteProduct(context.Background(), products[len(products)-1].ID)
+	assert.NoError(t, err)
+
+	// Test Delete is soft, the product is hidden but can be restored
+	_, err = repo.GetProductByID(context.Background(), products[len(products)-1].ID)
+	assert.Error(t, err)
+	restoredProduct, err := repo.RestoreProduct(context.Background(), products[len(products)-1].ID)
+	assert.NoError(t, err)
+	assert.False(t, restoredProduct.DeletedAt.Valid)
+
+	// Test Purge only removes products deleted before the cutoff
+	err = repo.DeleteProduct(context.Background(), restoredProduct.ID)
+	assert.NoError(t, err)
+	_, err = repo.PurgeDeletedProducts(context.Background(), time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	_, err = repo.RestoreProduct(context.Background(), restoredProduct.ID)
 	assert.NoError(t, err)
 }
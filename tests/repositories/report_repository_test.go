@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTimeseriesPeriods(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		interval string
+		want     []time.Time
+	}{
+		{
+			name:     "days",
+			from:     date(2024, time.February, 28),
+			to:       date(2024, time.March, 2),
+			interval: "day",
+			want:     []time.Time{date(2024, time.February, 28), date(2024, time.February, 29), date(2024, time.March, 1)},
+		},
+		{
+			name:     "day truncates the time of day",
+			from:     time.Date(2024, time.May, 1, 15, 30, 0, 0, time.UTC),
+			to:       date(2024, time.May, 2),
+			interval: "day",
+			want:     []time.Time{date(2024, time.May, 1)},
+		},
+		{
+			name:     "weeks start on Monday",
+			from:     date(2024, time.May, 1), // Wednesday
+			to:       date(2024, time.May, 13),
+			interval: "week",
+			want:     []time.Time{date(2024, time.April, 29), date(2024, time.May, 6)},
+		},
+		{
+			name:     "Sunday belongs to the week of the previous Monday",
+			from:     date(2024, time.May, 5), // Sunday
+			to:       date(2024, time.May, 7),
+			interval: "week",
+			want:     []time.Time{date(2024, time.April, 29), date(2024, time.May, 6)},
+		},
+		{
+			name:     "weeks across the year end",
+			from:     date(2024, time.December, 31),
+			to:       date(2025, time.January, 7),
+			interval: "week",
+			want:     []time.Time{date(2024, time.December, 30), date(2025, time.January, 6)},
+		},
+		{
+			name:     "months from the end of a month",
+			from:     date(2024, time.January, 31),
+			to:       date(2024, time.March, 2),
+			interval: "month",
+			want:     []time.Time{date(2024, time.January, 1), date(2024, time.February, 1), date(2024, time.March, 1)},
+		},
+		{
+			name:     "months across the year end",
+			from:     date(2024, time.November, 15),
+			to:       date(2025, time.January, 2),
+			interval: "month",
+			want:     []time.Time{date(2024, time.November, 1), date(2024, time.December, 1), date(2025, time.January, 1)},
+		},
+		{
+			name:     "empty range",
+			from:     date(2024, time.May, 1),
+			to:       date(2024, time.May, 1),
+			interval: "day",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods, err := repositories.TimeseriesPeriods(tt.from, tt.to, tt.interval)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, periods)
+		})
+	}
+}
+
+func TestTimeseriesPeriodsBucketLimit(t *testing.T) {
+	from := date(2020, time.January, 1)
+
+	// Exactly 1000 daily buckets are allowed
+	periods, err := repositories.TimeseriesPeriods(from, from.AddDate(0, 0, 1000), "day")
+	assert.NoError(t, err)
+	assert.Len(t, periods, 1000)
+
+	// One more is rejected
+	_, err = repositories.TimeseriesPeriods(from, from.AddDate(0, 0, 1001), "day")
+	assert.True(t, errors.Is(err, models.ErrInvalidDateRange))
+
+	// The limit counts buckets, so the same range is fine by month
+	periods, err = repositories.TimeseriesPeriods(from, from.AddDate(0, 0, 1001), "month")
+	assert.NoError(t, err)
+	assert.Len(t, periods, 33)
+}
+
+func TestFillTimeseries(t *testing.T) {
+	periods := []time.Time{date(2024, time.May, 1), date(2024, time.May, 2), date(2024, time.May, 3)}
+
+	tests := []struct {
+		name   string
+		points []models.SalesTimeseriesPoint
+		want   []models.SalesTimeseriesPoint
+	}{
+		{
+			name:   "no sales",
+			points: nil,
+			want: []models.SalesTimeseriesPoint{
+				{Period: "2024-05-01"}, {Period: "2024-05-02"}, {Period: "2024-05-03"},
+			},
+		},
+		{
+			name: "gaps are zero filled",
+			points: []models.SalesTimeseriesPoint{
+				{Period: "2024-05-02", Revenue: 30, UnitsSold: 3, Orders: 2},
+			},
+			want: []models.SalesTimeseriesPoint{
+				{Period: "2024-05-01"},
+				{Period: "2024-05-02", Revenue: 30, UnitsSold: 3, Orders: 2},
+				{Period: "2024-05-03"},
+			},
+		},
+		{
+			name: "points outside the periods are dropped",
+			points: []models.SalesTimeseriesPoint{
+				{Period: "2024-05-03", Revenue: 10, UnitsSold: 1, Orders: 1},
+				{Period: "2024-05-04", Revenue: 20, UnitsSold: 2, Orders: 1},
+			},
+			want: []models.SalesTimeseriesPoint{
+				{Period: "2024-05-01"},
+				{Period: "2024-05-02"},
+				{Period: "2024-05-03", Revenue: 10, UnitsSold: 1, Orders: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, repositories.FillTimeseries(periods, tt.points))
+		})
+	}
+}
+
+func TestProductSalesReportSkipsDeletedCategories(t *testing.T) {
+	// The query is only built and logged, no database is needed. Scanning it fails in dry run mode,
+	// after it is logged
+	var sql bytes.Buffer
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.New(log.New(&sql, "", 0), logger.Config{LogLevel: logger.Info}),
+	})
+	assert.NoError(t, err)
+
+	_, err = repositories.NewReportRepository(db).GenerateProductSalesReport(context.Background(), models.ProductSalesQuery{Page: 1, PageSize: 10})
+	assert.ErrorIs(t, err, gorm.ErrDryRunModeUnsupported)
+
+	// A product of a deleted category is listed without its category name
+	assert.Contains(t, sql.String(), "LEFT JOIN categories c ON p.category_id = c.id AND c.deleted_at IS NULL")
+}
@@ -0,0 +1,87 @@
+package services_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAPIKeyRepository(ctrl)
+	service := services.NewAPIKeyService(mockRepository)
+
+	var stored models.APIKey
+	mockRepository.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, apiKey *models.APIKey) error {
+		stored = *apiKey
+		return nil
+	})
+
+	created, err := service.CreateAPIKey(context.Background(), models.CreateAPIKeyRequest{Name: "erp", Scopes: []string{models.ScopeProductsRead}})
+
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+	sum := sha256.Sum256([]byte(created.Key))
+	assert.Equal(t, hex.EncodeToString(sum[:]), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, created.Key)
+}
+
+func TestCreateAPIKeyExpiryInPast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAPIKeyRepository(ctrl)
+	service := services.NewAPIKeyService(mockRepository)
+
+	expiresAt := time.Now().Add(-time.Hour)
+	_, err := service.CreateAPIKey(context.Background(), models.CreateAPIKeyRequest{Name: "erp", Scopes: []string{models.ScopeProductsRead}, ExpiresAt: &expiresAt})
+
+	assert.ErrorIs(t, err, models.ErrExpiryInPast)
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAPIKeyRepository(ctrl)
+	service := services.NewAPIKeyService(mockRepository)
+
+	sum := sha256.Sum256([]byte("ek_valid"))
+	mockRepository.EXPECT().GetAPIKeyByHash(gomock.Any(), hex.EncodeToString(sum[:])).Return(models.APIKey{ID: 1, Scopes: []string{models.ScopeReportsRead}}, nil)
+
+	apiKey, err := service.Authenticate(context.Background(), "ek_valid")
+
+	assert.Nil(t, err)
+	assert.True(t, apiKey.HasScope(models.ScopeReportsRead))
+}
+
+func TestAuthenticateAPIKeyInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAPIKeyRepository(ctrl)
+	service := services.NewAPIKeyService(mockRepository)
+
+	expiredAt := time.Now().Add(-time.Minute)
+	sum := sha256.Sum256([]byte("ek_expired"))
+	mockRepository.EXPECT().GetAPIKeyByHash(gomock.Any(), hex.EncodeToString(sum[:])).Return(models.APIKey{ID: 1, ExpiresAt: &expiredAt}, nil)
+	mockRepository.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(models.APIKey{}, errors.New("record not found"))
+
+	_, err := service.Authenticate(context.Background(), "ek_expired")
+	assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+
+	_, err = service.Authenticate(context.Background(), "ek_unknown")
+	assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+}
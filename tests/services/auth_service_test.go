@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var jwtSecret = []byte("test-secret")
+
+func newUser(t *testing.T, password string, role string) models.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.Nil(t, err)
+	return models.User{ID: 1, Email: "user@mail.com", PasswordHash: string(hash), Role: role}
+}
+
+func TestLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockUserRepository(ctrl)
+	service := services.NewAuthService(mockRepository, jwtSecret, time.Minute, time.Hour)
+
+	user := newUser(t, "secret", models.RoleEditor)
+	mockRepository.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").Return(user, nil)
+
+	tokens, err := service.Login(context.Background(), "user@mail.com", "secret")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.Equal(t, int64(60), tokens.ExpiresIn)
+
+	authUser, err := service.ParseAccessToken(tokens.AccessToken)
+	assert.Nil(t, err)
+	assert.Equal(t, models.AuthUser{ID: 1, Role: models.RoleEditor}, authUser)
+}
+
+func TestLoginWrongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockUserRepository(ctrl)
+	service := services.NewAuthService(mockRepository, jwtSecret, time.Minute, time.Hour)
+
+	mockRepository.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").Return(newUser(t, "secret", models.RoleViewer), nil)
+
+	_, err := service.Login(context.Background(), "user@mail.com", "wrong")
+
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+}
+
+func TestLoginUnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockUserRepository(ctrl)
+	service := services.NewAuthService(mockRepository, jwtSecret, time.Minute, time.Hour)
+
+	mockRepository.EXPECT().GetUserByEmail(gomock.Any(), "nobody@mail.com").Return(models.User{}, errors.New("record not found"))
+
+	_, err := service.Login(context.Background(), "nobody@mail.com", "secret")
+
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+}
+
+func TestRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockUserRepository(ctrl)
+	service := services.NewAuthService(mockRepository, jwtSecret, time.Minute, time.Hour)
+
+	user := newUser(t, "secret", models.RoleViewer)
+	mockRepository.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").Return(user, nil)
+	tokens, _ := service.Login(context.Background(), "user@mail.com", "secret")
+
+	// The role changed since the refresh token was issued
+	user.Role = models.RoleAdmin
+	mockRepository.EXPECT().GetUserByID(gomock.Any(), uint(1)).Return(user, nil)
+
+	refreshed, err := service.Refresh(context.Background(), tokens.RefreshToken)
+
+	assert.Nil(t, err)
+	authUser, err := service.ParseAccessToken(refreshed.AccessToken)
+	assert.Nil(t, err)
+	assert.Equal(t, models.RoleAdmin, authUser.Role)
+}
+
+func TestRefreshRejectsAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockUserRepository(ctrl)
+	service := services.NewAuthService(mockRepository, jwtSecret, time.Minute, time.Hour)
+
+	mockRepository.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").Return(newUser(t, "secret", models.RoleViewer), nil)
+	tokens, _ := service.Login(context.Background(), "user@mail.com", "secret")
+
+	_, err := service.Refresh(context.Background(), tokens.AccessToken)
+
+	assert.ErrorIs(t, err, models.ErrInvalidToken)
+}
+
+func TestParseAccessTokenInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockUserRepository(ctrl)
+	service := services.NewAuthService(mockRepository, jwtSecret, time.Minute, time.Hour)
+	otherService := services.NewAuthService(mockRepository, []byte("other-secret"), time.Minute, time.Hour)
+	expiredService := services.NewAuthService(mockRepository, jwtSecret, -time.Minute, time.Hour)
+
+	mockRepository.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").Return(newUser(t, "secret", models.RoleViewer), nil).Times(2)
+	foreignTokens, _ := otherService.Login(context.Background(), "user@mail.com", "secret")
+	expiredTokens, _ := expiredService.Login(context.Background(), "user@mail.com", "secret")
+
+	for _, token := range []string{"garbage", foreignTokens.AccessToken, expiredTokens.AccessToken} {
+		_, err := service.ParseAccessToken(token)
+		assert.ErrorIs(t, err, models.ErrInvalidToken)
+	}
+}
+
+func TestEnsureAdminUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockUserRepository(ctrl)
+	service := services.NewAuthService(mockRepository, jwtSecret, time.Minute, time.Hour)
+
+	mockRepository.EXPECT().GetUserByEmail(gomock.Any(), "admin@mail.com").Return(models.User{}, errors.New("record not found"))
+	mockRepository.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user *models.User) error {
+		assert.Equal(t, models.RoleAdmin, user.Role)
+		assert.Nil(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("admin123")))
+		return nil
+	})
+
+	err := service.EnsureAdminUser(context.Background(), "admin@mail.com", "admin123")
+
+	assert.Nil(t, err)
+}
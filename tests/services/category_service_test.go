@@ -1,9 +1,11 @@
 package services_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
 	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
@@ -15,12 +17,12 @@ func TestGetAllCategories(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockCategoryRepository(ctrl)
-	service := services.NewCategoryService(mockRepository)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
 
 	var categories []models.Category
-	mockRepository.EXPECT().GetAllCategories().Return(categories, nil).Times(1)
+	mockRepository.EXPECT().GetAllCategories(gomock.Any()).Return(categories, nil).Times(1)
 
-	result, err := service.GetAllCategories()
+	result, err := service.GetAllCategories(context.Background())
 
 	assert.Nil(t, err)
 	assert.Equal(t, categories, result)
@@ -31,12 +33,12 @@ func TestGetCategoryByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockCategoryRepository(ctrl)
-	service := services.NewCategoryService(mockRepository)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
 
 	category := models.Category{ID: 1}
-	mockRepository.EXPECT().GetCategoryByID(uint(1)).Return(category, nil).Times(1)
+	mockRepository.EXPECT().GetCategoryByID(gomock.Any(), uint(1)).Return(category, nil).Times(1)
 
-	result, err := service.GetCategoryByID(uint(1))
+	result, err := service.GetCategoryByID(context.Background(), uint(1))
 
 	assert.Nil(t, err)
 	assert.Equal(t, category, result)
@@ -47,12 +49,12 @@ func TestCreateCategory(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockCategoryRepository(ctrl)
-	service := services.NewCategoryService(mockRepository)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
 
 	category := models.Category{}
-	mockRepository.EXPECT().CreateCategory(&category).Return(nil)
+	mockRepository.EXPECT().CreateCategory(gomock.Any(), &category).Return(nil)
 
-	err := service.CreateCategory(&category)
+	err := service.CreateCategory(context.Background(), &category)
 
 	assert.Nil(t, err)
 }
@@ -62,12 +64,12 @@ func TestUpdateCategory(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockCategoryRepository(ctrl)
-	service := services.NewCategoryService(mockRepository)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
 
 	category := models.Category{ID: 1}
-	mockRepository.EXPECT().UpdateCategory(&category).Return(nil)
+	mockRepository.EXPECT().UpdateCategory(gomock.Any(), &category).Return(nil)
 
-	err := service.UpdateCategory(&category)
+	err := service.UpdateCategory(context.Background(), &category)
 
 	assert.Nil(t, err)
 }
@@ -77,11 +79,115 @@ func TestDeleteCategory(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockCategoryRepository(ctrl)
-	service := services.NewCategoryService(mockRepository)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
 
-	mockRepository.EXPECT().DeleteCategory(uint(1)).Return(nil).Times(1)
+	mockRepository.EXPECT().DeleteCategory(gomock.Any(), uint(1)).Return(nil).Times(1)
 
-	err := service.DeleteCategory(uint(1))
+	err := service.DeleteCategory(context.Background(), uint(1))
 
 	assert.Nil(t, err)
 }
+
+func TestReassignProductsAndDeleteCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCategoryRepository(ctrl)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
+
+	mockRepository.EXPECT().ReassignProductsAndDeleteCategory(gomock.Any(), uint(1), uint(2)).Return(nil).Times(1)
+
+	err := service.ReassignProductsAndDeleteCategory(context.Background(), uint(1), uint(2))
+
+	assert.Nil(t, err)
+}
+
+func TestDeactivateProductsAndDeleteCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCategoryRepository(ctrl)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
+
+	mockRepository.EXPECT().DeactivateProductsAndDeleteCategory(gomock.Any(), uint(1)).Return(nil).Times(1)
+
+	err := service.DeactivateProductsAndDeleteCategory(context.Background(), uint(1))
+
+	assert.Nil(t, err)
+}
+
+func TestGetCategoryTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCategoryRepository(ctrl)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
+
+	rootID, childID := uint(1), uint(2)
+	mockRepository.EXPECT().GetAllCategories(gomock.Any()).Return([]models.Category{
+		{ID: 1, Name: "Electronics"},
+		{ID: 2, Name: "Phones", ParentID: &rootID},
+		{ID: 3, Name: "Smartphones", ParentID: &childID},
+		{ID: 4, Name: "Books"},
+	}, nil).Times(1)
+
+	result, err := service.GetCategoryTree(context.Background())
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "Phones", result[0].Children[0].Name)
+	assert.Equal(t, "Smartphones", result[0].Children[0].Children[0].Name)
+	assert.Empty(t, result[1].Children)
+}
+
+func TestCreateCategoryParentNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCategoryRepository(ctrl)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
+
+	parentID := uint(9)
+	mockRepository.EXPECT().GetAllCategories(gomock.Any()).Return([]models.Category{{ID: 1}}, nil).Times(1)
+
+	err := service.CreateCategory(context.Background(), &models.Category{Name: "Phones", ParentID: &parentID})
+
+	assert.ErrorIs(t, err, models.ErrCategoryNotFound)
+}
+
+func TestUpdateCategoryCycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCategoryRepository(ctrl)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
+
+	rootID, childID := uint(1), uint(2)
+	mockRepository.EXPECT().GetAllCategories(gomock.Any()).Return([]models.Category{
+		{ID: 1, Name: "Electronics"},
+		{ID: 2, Name: "Phones", ParentID: &rootID},
+		{ID: 3, Name: "Smartphones", ParentID: &childID},
+	}, nil).Times(1)
+
+	// Moving the root under its own grandchild would create a cycle
+	grandchildID := uint(3)
+	err := service.UpdateCategory(context.Background(), &models.Category{ID: 1, Name: "Electronics", ParentID: &grandchildID})
+
+	assert.ErrorIs(t, err, models.ErrCategoryCycle)
+}
+
+func TestRestoreCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCategoryRepository(ctrl)
+	service := services.NewCategoryService(mockRepository, cache.NewMemoryCache())
+
+	category := models.Category{ID: 1}
+	mockRepository.EXPECT().RestoreCategory(gomock.Any(), uint(1)).Return(category, nil).Times(1)
+
+	result, err := service.RestoreCategory(context.Background(), uint(1))
+
+	assert.Nil(t, err)
+	assert.Equal(t, category, result)
+}
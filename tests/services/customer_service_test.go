@@ -0,0 +1,109 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCustomerRepository(ctrl)
+	service := services.NewCustomerService(mockRepository, cache.NewMemoryCache())
+
+	customer := models.Customer{Email: "jane@example.com"}
+	mockRepository.EXPECT().IsEmailTaken(gomock.Any(), "jane@example.com", uint(0)).Return(false, nil)
+	mockRepository.EXPECT().CreateCustomer(gomock.Any(), &customer).Return(nil)
+
+	err := service.CreateCustomer(context.Background(), &customer)
+
+	assert.Nil(t, err)
+}
+
+func TestCreateCustomerEmailTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCustomerRepository(ctrl)
+	service := services.NewCustomerService(mockRepository, cache.NewMemoryCache())
+
+	// The customer is not written, and a failed lookup is not taken for an available email
+	customer := models.Customer{Email: "jane@example.com"}
+	mockRepository.EXPECT().IsEmailTaken(gomock.Any(), "jane@example.com", uint(0)).Return(true, nil)
+	assert.ErrorIs(t, service.CreateCustomer(context.Background(), &customer), models.ErrEmailTaken)
+
+	mockRepository.EXPECT().IsEmailTaken(gomock.Any(), "jane@example.com", uint(0)).Return(false, errors.New("connection refused"))
+	assert.EqualError(t, service.CreateCustomer(context.Background(), &customer), "connection refused")
+}
+
+func TestUpdateCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCustomerRepository(ctrl)
+	service := services.NewCustomerService(mockRepository, cache.NewMemoryCache())
+
+	customer := models.Customer{ID: 1, Email: "jane@example.com"}
+	mockRepository.EXPECT().IsEmailTaken(gomock.Any(), "jane@example.com", uint(1)).Return(false, nil)
+	mockRepository.EXPECT().UpdateCustomer(gomock.Any(), &customer).Return(customer, nil).Times(1)
+
+	result, err := service.UpdateCustomer(context.Background(), &customer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, customer, result)
+}
+
+func TestDeleteCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCustomerRepository(ctrl)
+	service := services.NewCustomerService(mockRepository, cache.NewMemoryCache())
+
+	mockRepository.EXPECT().DeleteCustomer(gomock.Any(), uint(1)).Return(nil).Times(1)
+
+	err := service.DeleteCustomer(context.Background(), uint(1))
+
+	assert.Nil(t, err)
+}
+
+func TestIsEmailTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCustomerRepository(ctrl)
+	service := services.NewCustomerService(mockRepository, cache.NewMemoryCache())
+
+	mockRepository.EXPECT().IsEmailTaken(gomock.Any(), "jane@example.com", uint(0)).Return(true, nil).Times(1)
+
+	result, err := service.IsEmailTaken(context.Background(), "jane@example.com", uint(0))
+
+	assert.Nil(t, err)
+	assert.True(t, result)
+}
+
+func TestGetCustomerOrdersWithPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockCustomerRepository(ctrl)
+	service := services.NewCustomerService(mockRepository, cache.NewMemoryCache())
+
+	ordersPageable := models.OrdersPageable{}
+	mockRepository.EXPECT().GetCustomerOrdersWithPagination(gomock.Any(), uint(1)).Return(ordersPageable, nil)
+
+	result, err := service.GetCustomerOrdersWithPagination(&gin.Context{}, uint(1))
+
+	assert.Nil(t, err)
+	assert.Equal(t, ordersPageable, result)
+}
@@ -0,0 +1,66 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadinessUp(t *testing.T) {
+	service := services.NewHealthService(map[string]services.HealthCheck{
+		"mysql": func(ctx context.Context) error { return nil },
+		"redis": func(ctx context.Context) error { return nil },
+	}, time.Second)
+
+	report := service.Readiness(context.Background())
+
+	assert.True(t, report.IsUp())
+	assert.Equal(t, models.HealthStatusUp, report.Checks["mysql"].Status)
+	assert.Equal(t, models.HealthStatusUp, report.Checks["redis"].Status)
+}
+
+func TestReadinessDependencyDown(t *testing.T) {
+	service := services.NewHealthService(map[string]services.HealthCheck{
+		"mysql": func(ctx context.Context) error { return nil },
+		"redis": func(ctx context.Context) error { return errors.New("connection refused") },
+	}, time.Second)
+
+	report := service.Readiness(context.Background())
+
+	assert.False(t, report.IsUp())
+	assert.Equal(t, models.HealthStatusUp, report.Checks["mysql"].Status)
+	assert.Equal(t, models.HealthStatusDown, report.Checks["redis"].Status)
+	assert.Equal(t, "connection refused", report.Checks["redis"].Error)
+}
+
+func TestReadinessCheckTimeout(t *testing.T) {
+	service := services.NewHealthService(map[string]services.HealthCheck{
+		"mysql": func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}, 10*time.Millisecond)
+
+	report := service.Readiness(context.Background())
+
+	assert.False(t, report.IsUp())
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["mysql"].Error)
+}
+
+func TestReadinessShuttingDown(t *testing.T) {
+	service := services.NewHealthService(map[string]services.HealthCheck{
+		"mysql": func(ctx context.Context) error { return nil },
+	}, time.Second)
+
+	service.MarkShuttingDown()
+	report := service.Readiness(context.Background())
+
+	assert.False(t, report.IsUp())
+	assert.True(t, report.ShuttingDown)
+	assert.Equal(t, models.HealthStatusUp, report.Checks["mysql"].Status)
+}
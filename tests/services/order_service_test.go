@@ -0,0 +1,61 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockOrderRepository(ctrl)
+	service := services.NewOrderService(mockRepository, cache.NewMemoryCache())
+
+	order := models.Order{}
+	mockRepository.EXPECT().CreateOrder(gomock.Any(), &order).Return(nil)
+
+	err := service.CreateOrder(context.Background(), &order)
+
+	assert.Nil(t, err)
+}
+
+func TestGetAllOrdersWithPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockOrderRepository(ctrl)
+	service := services.NewOrderService(mockRepository, cache.NewMemoryCache())
+
+	ordersPageable := models.OrdersPageable{}
+	mockRepository.EXPECT().GetAllOrdersWithPagination(gomock.Any()).Return(ordersPageable, nil)
+
+	result, err := service.GetAllOrdersWithPagination(&gin.Context{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, ordersPageable, result)
+}
+
+func TestGetOrderByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockOrderRepository(ctrl)
+	service := services.NewOrderService(mockRepository, cache.NewMemoryCache())
+
+	order := models.Order{ID: 1}
+	mockRepository.EXPECT().GetOrderByID(gomock.Any(), uint(1)).Return(order, nil).Times(1)
+
+	result, err := service.GetOrderByID(context.Background(), uint(1))
+
+	assert.Nil(t, err)
+	assert.Equal(t, order, result)
+}
@@ -1,10 +1,12 @@
 package services_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
 	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
 	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
@@ -16,12 +18,12 @@ func TestCreateProduct(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockProductRepository(ctrl)
-	service := services.NewProductService(mockRepository)
+	service := services.NewProductService(mockRepository, cache.NewMemoryCache())
 
 	product := models.Product{}
-	mockRepository.EXPECT().CreateProduct(&product).Return(nil)
+	mockRepository.EXPECT().CreateProduct(gomock.Any(), &product).Return(nil)
 
-	err := service.CreateProduct(&product)
+	err := service.CreateProduct(context.Background(), &product)
 
 	assert.Nil(t, err)
 }
@@ -31,12 +33,12 @@ func TestUpdateProduct(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockProductRepository(ctrl)
-	service := services.NewProductService(mockRepository)
+	service := services.NewProductService(mockRepository, cache.NewMemoryCache())
 
 	product := models.Product{ID: 1}
-	mockRepository.EXPECT().UpdateProduct(&product).Return(product, nil).Times(1)
+	mockRepository.EXPECT().UpdateProduct(gomock.Any(), &product).Return(product, nil).Times(1)
 
-	result, err := service.UpdateProduct(&product)
+	result, err := service.UpdateProduct(context.Background(), &product)
 
 	assert.Nil(t, err)
 	assert.Equal(t, product, result)
@@ -47,11 +49,11 @@ func TestDeleteProduct(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockProductRepository(ctrl)
-	service := services.NewProductService(mockRepository)
+	service := services.NewProductService(mockRepository, cache.NewMemoryCache())
 
-	mockRepository.EXPECT().DeleteProduct(uint(1)).Return(nil).Times(1)
+	mockRepository.EXPECT().DeleteProduct(gomock.Any(), uint(1)).Return(nil).Times(1)
 
-	err := service.DeleteProduct(uint(1))
+	err := service.DeleteProduct(context.Background(), uint(1))
 
 	assert.Nil(t, err)
 }
@@ -61,12 +63,12 @@ func TestGetAllProduct(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockProductRepository(ctrl)
-	service := services.NewProductService(mockRepository)
+	service := services.NewProductService(mockRepository, cache.NewMemoryCache())
 
 	products := []models.Product{}
-	mockRepository.EXPECT().GetAllProducts().Return(products, nil).Times(1)
+	mockRepository.EXPECT().GetAllProducts(gomock.Any()).Return(products, nil).Times(1)
 
-	result, err := service.GetAllProducts()
+	result, err := service.GetAllProducts(context.Background())
 
 	assert.Nil(t, err)
 	assert.Equal(t, products, result)
@@ -77,7 +79,7 @@ func TestGetAllProductsWithPagination(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockProductRepository(ctrl)
-	service := services.NewProductService(mockRepository)
+	service := services.NewProductService(mockRepository, cache.NewMemoryCache())
 
 	productsPageable := models.ProductsPageable{}
 	mockRepository.EXPECT().GetAllProductsWithPagination(gomock.Any()).Return(productsPageable, nil)
@@ -93,12 +95,28 @@ func TestGetProductByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockProductRepository(ctrl)
-	service := services.NewProductService(mockRepository)
+	service := services.NewProductService(mockRepository, cache.NewMemoryCache())
 
 	product := models.Product{}
-	mockRepository.EXPECT().GetProductByID(uint(1)).Return(product, nil).Times(1)
+	mockRepository.EXPECT().GetProductByID(gomock.Any(), uint(1)).Return(product, nil).Times(1)
 
-	result, err := service.GetProductByID(uint(1))
+	result, err := service.GetProductByID(context.Background(), uint(1))
+
+	assert.Nil(t, err)
+	assert.Equal(t, product, result)
+}
+
+func TestRestoreProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockProductRepository(ctrl)
+	service := services.NewProductService(mockRepository, cache.NewMemoryCache())
+
+	product := models.Product{ID: 1}
+	mockRepository.EXPECT().RestoreProduct(gomock.Any(), uint(1)).Return(product, nil).Times(1)
+
+	result, err := service.RestoreProduct(context.Background(), uint(1))
 
 	assert.Nil(t, err)
 	assert.Equal(t, product, result)
@@ -0,0 +1,572 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/cache"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/configs"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/models"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/services"
+	"github.com/ndkode/elabram-backend-recruitment/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// newReportContext returns a gin context for a request to target
+func newReportContext(target string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return ctx
+}
+
+// newCacheConfig returns a report cache configuration where reports are fresh for ttl and never stale
+func newCacheConfig(ttl time.Duration) configs.CacheConfig {
+	return configs.CacheConfig{ReportTTL: ttl, ReportLockTimeout: time.Second}
+}
+
+func TestGenerateProductReportCachesReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	// The second call is served from the cache
+	mockRepository.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).Return(models.ProductReport{
+		TotalProducts: 3,
+		TotalStock:    12,
+		AvgPrice:      20.5,
+		Products: []models.Product{
+			{ID: 1, Name: "Laptop", Price: 20.5, CategoryID: 2, Category: &models.Category{ID: 2, Name: "Computers"}, CreatedAt: time.Now()},
+		},
+	}, nil).Times(1)
+
+	first, firstStatus, err := service.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+	assert.Nil(t, err)
+	second, secondStatus, err := service.GenerateProductReport(newReportContext("/reports/products?page=1&sort_by=name"), models.ReportQuery{})
+	assert.Nil(t, err)
+
+	// Clients get the same JSON whether the report comes from the cache or not
+	firstJSON, err := json.Marshal(first)
+	assert.Nil(t, err)
+	secondJSON, err := json.Marshal(second)
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(firstJSON), string(secondJSON))
+	assert.Equal(t, int64(3), second.TotalProducts)
+	assert.False(t, firstStatus.Hit)
+	assert.True(t, secondStatus.Hit)
+}
+
+func TestGenerateProductReportOptimized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	mockRepository.EXPECT().GenerateProductReportWithGoroutines(gomock.Any(), gomock.Any()).Return(models.ProductReport{TotalProducts: 3}, nil)
+
+	_, _, err := service.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{Strategy: models.ReportStrategyGoroutines})
+
+	assert.Nil(t, err)
+}
+
+func TestGenerateProductReportSingleQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	query := models.ReportQuery{Strategy: models.ReportStrategySingleQuery, SortBy: "name", SortOrder: "asc", Page: 1, PageSize: 10}
+	mockRepository.EXPECT().GenerateProductReportSingleQuery(gomock.Any(), query).Return(models.ProductReport{TotalProducts: 3}, nil)
+
+	report, _, err := service.GenerateProductReport(newReportContext("/reports/products?strategy=single_query"), query)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), report.TotalProducts)
+}
+
+func TestExportProductReportIsNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	reportCache := cache.NewMemoryCache()
+	service := services.NewReportService(mockRepository, reportCache, newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	// Every export reads the rows from the repository
+	query := models.ReportQuery{SortBy: "name", SortOrder: "asc", Page: 1, PageSize: 10}
+	mockRepository.EXPECT().ExportProductReport(gomock.Any(), query, gomock.Any()).Times(2).DoAndReturn(
+		func(ctx context.Context, query models.ReportQuery, write func(row models.ProductExportRow) error) error {
+			return write(models.ProductExportRow{ID: 1, Name: "Laptop"})
+		})
+
+	for i := 0; i < 2; i++ {
+		var rows []models.ProductExportRow
+		err := service.ExportProductReport(newReportContext("/reports/products?format=csv"), query, func(row models.ProductExportRow) error {
+			rows = append(rows, row)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []models.ProductExportRow{{ID: 1, Name: "Laptop"}}, rows)
+	}
+}
+
+func TestExportProductReportLimitsConcurrentExports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	// The exports in progress block in the repository until released
+	release := make(chan struct{})
+	started := make(chan struct{}, 5)
+	mockRepository.EXPECT().ExportProductReport(gomock.Any(), gomock.Any(), gomock.Any()).Times(5).DoAndReturn(
+		func(ctx context.Context, query models.ReportQuery, write func(row models.ProductExportRow) error) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, service.ExportProductReport(newReportContext("/reports/products?format=csv"), models.ReportQuery{}, nil))
+		}()
+		<-started
+	}
+
+	err := service.ExportProductReport(newReportContext("/reports/products?format=csv"), models.ReportQuery{}, nil)
+	assert.ErrorIs(t, err, models.ErrTooManyExports)
+
+	// The slots are freed once the exports are done
+	close(release)
+	wg.Wait()
+	assert.Nil(t, service.ExportProductReport(newReportContext("/reports/products?format=csv"), models.ReportQuery{}, nil))
+}
+
+func TestGenerateTopCustomersReportCacheExpires(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(10*time.Millisecond), new(sync.WaitGroup))
+
+	mockRepository.EXPECT().GenerateTopCustomersReport(gomock.Any(), gomock.Any()).Return([]models.TopCustomer{{ID: 1}}, nil).Times(2)
+
+	_, _, err := service.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+	assert.Nil(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, _, err = service.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+	assert.Nil(t, err)
+}
+
+func TestGenerateProductReportCacheKeyCoversFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	// Every filter, sort and strategy yields its own report
+	minPrice := 10.0
+	queries := []models.ReportQuery{
+		{Name: "foo"},
+		{CategoryID: 2},
+		{CategoryID: 2, IncludeDescendants: true},
+		{MinPrice: &minPrice},
+		{SortBy: "price"},
+		{SortBy: "price", SortOrder: "desc"},
+		{Page: 2},
+		{Strategy: models.ReportStrategySingleQuery},
+	}
+	mockRepository.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).Return(models.ProductReport{TotalProducts: 1}, nil).Times(len(queries) - 1)
+	mockRepository.EXPECT().GenerateProductReportSingleQuery(gomock.Any(), gomock.Any()).Return(models.ProductReport{TotalProducts: 1}, nil).Times(1)
+
+	for _, query := range queries {
+		_, status, err := service.GenerateProductReport(newReportContext("/reports/products"), query)
+		assert.Nil(t, err)
+		assert.False(t, status.Hit, query)
+	}
+}
+
+func TestGenerateSalesReportsCacheKeyCoversQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	// The reports are generated with the query they are cached for, each query gets its own report
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	customersQueries := []models.TopCustomersQuery{{Limit: 10}, {Limit: 20}, {From: &from, Limit: 10}}
+	for _, query := range customersQueries {
+		mockRepository.EXPECT().GenerateTopCustomersReport(gomock.Any(), query).Return([]models.TopCustomer{{ID: 1}}, nil).Times(1)
+	}
+	timeseriesQueries := []models.SalesTimeseriesQuery{{Interval: "day"}, {Interval: "week"}, {Interval: "day", CategoryID: 2}, {Interval: "day", CategoryID: 2, IncludeDescendants: true}}
+	for _, query := range timeseriesQueries {
+		mockRepository.EXPECT().GenerateSalesTimeseriesReport(gomock.Any(), query).Return(models.SalesTimeseriesReport{}, nil).Times(1)
+	}
+
+	for range 2 {
+		for i, query := range customersQueries {
+			_, _, err := service.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), query)
+			assert.Nil(t, err, i)
+		}
+		for i, query := range timeseriesQueries {
+			_, _, err := service.GenerateSalesTimeseriesReport(newReportContext("/reports/sales-timeseries"), query)
+			assert.Nil(t, err, i)
+		}
+	}
+}
+
+func TestGenerateProductReportCacheKeyIgnoresSpelling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	mockRepository.EXPECT().GenerateProductReportWithGoroutines(gomock.Any(), gomock.Any()).Return(models.ProductReport{TotalProducts: 1}, nil).Times(1)
+
+	// The key comes from the parsed query, not from the query string it was parsed from
+	query := models.ReportQuery{SortBy: "name", SortOrder: "asc", Page: 1, PageSize: 10, Strategy: models.ReportStrategyGoroutines}
+	_, status, err := service.GenerateProductReport(newReportContext("/reports/products?is_optimized=true&page=01"), query)
+	assert.Nil(t, err)
+	assert.False(t, status.Hit)
+	_, status, err = service.GenerateProductReport(newReportContext("/reports/products?strategy=goroutines&page=1"), query)
+	assert.Nil(t, err)
+	assert.True(t, status.Hit)
+}
+
+func TestGenerateProductReportCollapsesConcurrentMisses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	// The report is generated once for all the concurrent requests
+	mockRepository.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, models.ReportQuery) (models.ProductReport, error) {
+		time.Sleep(100 * time.Millisecond)
+		return models.ProductReport{TotalProducts: 3}, nil
+	}).Times(1)
+
+	var wg sync.WaitGroup
+	statuses := make([]models.ReportCacheStatus, 10)
+	for i := range statuses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, status, err := service.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+			assert.Nil(t, err)
+			statuses[i] = status
+		}()
+	}
+	wg.Wait()
+
+	for _, status := range statuses {
+		assert.False(t, status.Hit)
+		assert.True(t, status.Collapsed)
+	}
+}
+
+func TestGenerateProductReportReturnsWhenClientDisconnects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	// The generation outlives the request that started it, and its report is cached
+	mockRepository.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ models.ReportQuery) (models.ProductReport, error) {
+		time.Sleep(200 * time.Millisecond)
+		return models.ProductReport{TotalProducts: 3}, ctx.Err()
+	}).Times(1)
+
+	ctx := newReportContext("/reports/products")
+	requestCtx, cancel := context.WithCancel(ctx.Request.Context())
+	ctx.Request = ctx.Request.WithContext(requestCtx)
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	started := time.Now()
+	_, _, err := service.GenerateProductReport(ctx, models.ReportQuery{})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(started), 150*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		report, status, err := service.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+		return err == nil && status.Hit && report.TotalProducts == 3
+	}, time.Second, 20*time.Millisecond)
+}
+
+func TestGenerateTopCustomersReportWaitsForOtherInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Two instances sharing the cache, the second waits for the report the first is generating
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	reportCache := cache.NewMemoryCache()
+	first := services.NewReportService(mockRepository, reportCache, newCacheConfig(time.Minute), new(sync.WaitGroup))
+	second := services.NewReportService(mockRepository, reportCache, newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	mockRepository.EXPECT().GenerateTopCustomersReport(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, models.TopCustomersQuery) ([]models.TopCustomer, error) {
+		time.Sleep(100 * time.Millisecond)
+		return []models.TopCustomer{{ID: 1}}, nil
+	}).Times(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _, err := first.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+		assert.Nil(t, err)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	report, status, err := second.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+	<-done
+
+	assert.Nil(t, err)
+	assert.Equal(t, []models.TopCustomer{{ID: 1}}, report)
+	assert.True(t, status.Collapsed)
+}
+
+func TestGenerateProductReportLockWaitSharesGenerationDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Both instances give up at their lock timeout, the second one spent waiting on the first included
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	reportCache := cache.NewMemoryCache()
+	config := configs.CacheConfig{ReportTTL: time.Minute, ReportLockTimeout: 100 * time.Millisecond}
+	first := services.NewReportService(mockRepository, reportCache, config, new(sync.WaitGroup))
+	second := services.NewReportService(mockRepository, reportCache, config, new(sync.WaitGroup))
+
+	mockRepository.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ models.ReportQuery) (models.ProductReport, error) {
+		select {
+		case <-ctx.Done():
+			return models.ProductReport{}, ctx.Err()
+		case <-time.After(time.Second):
+			return models.ProductReport{TotalProducts: 3}, nil
+		}
+	}).AnyTimes()
+
+	go first.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+	time.Sleep(20 * time.Millisecond)
+
+	started := time.Now()
+	_, _, err := second.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), 170*time.Millisecond)
+}
+
+func TestGenerateTopCustomersReportServesStaleReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	config := configs.CacheConfig{ReportTTL: 50 * time.Millisecond, ReportStaleTTL: time.Minute, ReportLockTimeout: time.Second}
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), config, new(sync.WaitGroup))
+
+	gomock.InOrder(
+		mockRepository.EXPECT().GenerateTopCustomersReport(gomock.Any(), gomock.Any()).Return([]models.TopCustomer{{ID: 1}}, nil),
+		mockRepository.EXPECT().GenerateTopCustomersReport(gomock.Any(), gomock.Any()).Return([]models.TopCustomer{{ID: 2}}, nil).MinTimes(1),
+	)
+
+	_, _, err := service.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+	assert.Nil(t, err)
+	time.Sleep(60 * time.Millisecond)
+
+	// The expired report is served while it is regenerated in the background
+	report, status, err := service.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, []models.TopCustomer{{ID: 1}}, report)
+	assert.True(t, status.Hit)
+	assert.Negative(t, status.TTL)
+
+	assert.Eventually(t, func() bool {
+		report, status, err := service.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+		return err == nil && status.Hit && status.TTL > 0 && report[0].ID == 2
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestStaleReportRefreshIsTracked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	config := configs.CacheConfig{ReportTTL: 50 * time.Millisecond, ReportStaleTTL: time.Minute, ReportLockTimeout: time.Second}
+	var background sync.WaitGroup
+	service := services.NewReportService(mockRepository, cache.NewMemoryCache(), config, &background)
+
+	refreshed := false
+	gomock.InOrder(
+		mockRepository.EXPECT().GenerateTopCustomersReport(gomock.Any(), gomock.Any()).Return([]models.TopCustomer{{ID: 1}}, nil),
+		mockRepository.EXPECT().GenerateTopCustomersReport(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, models.TopCustomersQuery) ([]models.TopCustomer, error) {
+			time.Sleep(100 * time.Millisecond)
+			refreshed = true
+			return []models.TopCustomer{{ID: 2}}, nil
+		}),
+	)
+
+	_, _, err := service.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+	assert.Nil(t, err)
+	time.Sleep(60 * time.Millisecond)
+	_, status, err := service.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.True(t, status.Hit)
+
+	// Shutdown waits for the refresh the request left running
+	background.Wait()
+	assert.True(t, refreshed)
+}
+
+func TestGenerateProductReportInvalidatedWhileGeneratedIsNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockReportRepository(ctrl)
+	reportCache := cache.NewMemoryCache()
+	service := services.NewReportService(mockRepository, reportCache, newCacheConfig(time.Minute), new(sync.WaitGroup))
+
+	// A product write lands while the first report is generated, so it is not cached
+	gomock.InOrder(
+		mockRepository.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ models.ReportQuery) (models.ProductReport, error) {
+			assert.Nil(t, reportCache.Invalidate(ctx, "products"))
+			return models.ProductReport{TotalProducts: 3}, nil
+		}),
+		mockRepository.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).Return(models.ProductReport{TotalProducts: 4}, nil),
+	)
+
+	report, status, err := service.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+	assert.Nil(t, err)
+	assert.False(t, status.Hit)
+	assert.Equal(t, int64(3), report.TotalProducts)
+
+	report, status, err = service.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+	assert.Nil(t, err)
+	assert.False(t, status.Hit)
+	assert.Equal(t, int64(4), report.TotalProducts)
+
+	report, status, err = service.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+	assert.Nil(t, err)
+	assert.True(t, status.Hit)
+	assert.Equal(t, int64(4), report.TotalProducts)
+}
+
+func TestProductWritesInvalidateReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepository := mocks.NewMockReportRepository(ctrl)
+	mockProductRepository := mocks.NewMockProductRepository(ctrl)
+	reportCache := cache.NewMemoryCache()
+	reportService := services.NewReportService(mockReportRepository, reportCache, newCacheConfig(time.Minute), new(sync.WaitGroup))
+	productService := services.NewProductService(mockProductRepository, reportCache)
+
+	// The products report is generated again after the update, the top customers report is not
+	mockReportRepository.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).Return(models.ProductReport{TotalProducts: 1}, nil).Times(2)
+	mockReportRepository.EXPECT().GenerateTopCustomersReport(gomock.Any(), gomock.Any()).Return([]models.TopCustomer{{ID: 1}}, nil).Times(1)
+	mockProductRepository.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).Return(models.Product{ID: 1}, nil)
+
+	_, _, err := reportService.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+	assert.Nil(t, err)
+	_, _, err = reportService.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+	assert.Nil(t, err)
+
+	_, err = productService.UpdateProduct(context.Background(), &models.Product{ID: 1})
+	assert.Nil(t, err)
+
+	_, status, err := reportService.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+	assert.Nil(t, err)
+	assert.False(t, status.Hit)
+	_, status, err = reportService.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.True(t, status.Hit)
+}
+
+func TestOrderAndCustomerWritesInvalidateReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepository := mocks.NewMockReportRepository(ctrl)
+	mockOrderRepository := mocks.NewMockOrderRepository(ctrl)
+	mockCustomerRepository := mocks.NewMockCustomerRepository(ctrl)
+	reportCache := cache.NewMemoryCache()
+	reportService := services.NewReportService(mockReportRepository, reportCache, newCacheConfig(time.Minute), new(sync.WaitGroup))
+	orderService := services.NewOrderService(mockOrderRepository, reportCache)
+	customerService := services.NewCustomerService(mockCustomerRepository, reportCache)
+
+	// An order changes the stock of the products report and the top customers, a customer update
+	// only the top customers
+	mockReportRepository.EXPECT().GenerateProductReport(gomock.Any(), gomock.Any()).Return(models.ProductReport{TotalStock: 5}, nil).Times(2)
+	mockReportRepository.EXPECT().GenerateTopCustomersReport(gomock.Any(), gomock.Any()).Return([]models.TopCustomer{{ID: 1}}, nil).Times(3)
+	mockOrderRepository.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(nil)
+	mockCustomerRepository.EXPECT().IsEmailTaken(gomock.Any(), gomock.Any(), uint(1)).Return(false, nil)
+	mockCustomerRepository.EXPECT().UpdateCustomer(gomock.Any(), gomock.Any()).Return(models.Customer{ID: 1}, nil)
+
+	generate := func() (productsHit, customersHit bool) {
+		_, productsStatus, err := reportService.GenerateProductReport(newReportContext("/reports/products"), models.ReportQuery{})
+		assert.Nil(t, err)
+		_, customersStatus, err := reportService.GenerateTopCustomersReport(newReportContext("/reports/top-customers"), models.TopCustomersQuery{Limit: 10})
+		assert.Nil(t, err)
+		return productsStatus.Hit, customersStatus.Hit
+	}
+
+	generate()
+	assert.Nil(t, orderService.CreateOrder(context.Background(), &models.Order{CustomerID: 1}))
+	productsHit, customersHit := generate()
+	assert.False(t, productsHit)
+	assert.False(t, customersHit)
+
+	_, err := customerService.UpdateCustomer(context.Background(), &models.Customer{ID: 1})
+	assert.Nil(t, err)
+	productsHit, customersHit = generate()
+	assert.True(t, productsHit)
+	assert.False(t, customersHit)
+}
+
+func TestCategoryReassignInvalidatesProductReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepository := mocks.NewMockReportRepository(ctrl)
+	mockCategoryRepository := mocks.NewMockCategoryRepository(ctrl)
+	reportCache := cache.NewMemoryCache()
+	reportService := services.NewReportService(mockReportRepository, reportCache, newCacheConfig(time.Minute), new(sync.WaitGroup))
+	categoryService := services.NewCategoryService(mockCategoryRepository, reportCache)
+
+	mockReportRepository.EXPECT().GenerateProductSalesReport(gomock.Any(), gomock.Any()).Return(models.ProductSalesPageable{Page: 1}, nil).Times(2)
+	mockCategoryRepository.EXPECT().ReassignProductsAndDeleteCategory(gomock.Any(), uint(1), uint(2)).Return(nil)
+
+	_, _, err := reportService.GenerateProductSalesReport(newReportContext("/reports/product-sales"), models.ProductSalesQuery{Page: 1, PageSize: 10})
+	assert.Nil(t, err)
+	assert.Nil(t, categoryService.ReassignProductsAndDeleteCategory(context.Background(), 1, 2))
+	_, status, err := reportService.GenerateProductSalesReport(newReportContext("/reports/product-sales"), models.ProductSalesQuery{Page: 1, PageSize: 10})
+
+	assert.Nil(t, err)
+	assert.False(t, status.Hit)
+}
+
+func TestPurgeReports(t *testing.T) {
+	reportCache := cache.NewMemoryCache()
+	service := services.NewCacheService(reportCache)
+	assert.Nil(t, reportCache.Set(context.Background(), "report:products:1", []byte("{}"), time.Minute, "reports", "products"))
+
+	assert.ErrorIs(t, service.PurgeReports(context.Background(), "widgets"), models.ErrUnknownCacheTag)
+	assert.Nil(t, service.PurgeReports(context.Background(), ""))
+
+	_, err := reportCache.Get(context.Background(), "report:products:1")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+}
@@ -0,0 +1,59 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ndkode/elabram-backend-recruitment/cmd/repositories"
+	"github.com/ndkode/elabram-backend-recruitment/cmd/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   uint
+	Name string
+}
+
+func TestGormPluginNestsQuerySpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	// Dry run builds the statements without a database connection
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Nil(t, err)
+	assert.Nil(t, db.Use(tracing.NewGormPlugin()))
+
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	db.WithContext(ctx).Find(&[]widget{})
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "gorm.query", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+}
+
+func TestRepositoryQuerySpansJoinTheRequestTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Nil(t, err)
+	assert.Nil(t, db.Use(tracing.NewGormPlugin()))
+
+	// The CRUD repositories run their statements on the request context, not a background one
+	ctx, parent := tracing.Start(context.Background(), "GET /orders/:id")
+	repositories.NewOrderRepository(db).GetOrderByID(ctx, 1)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "gorm.query", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}